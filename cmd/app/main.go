@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"github.com/ewik2k21/grpcOrderService/cmd/server"
 	"github.com/ewik2k21/grpcOrderService/config"
 	"log/slog"
@@ -9,13 +10,22 @@ import (
 )
 
 func main() {
-	cfg := config.InitConfig()
+	cfg, err := config.InitConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
 
 	ctx := context.Background()
 
+	//level is validated by InitConfig
+	level, _ := config.ParseLogLevel(cfg.LogLevel)
+	logLevel := new(slog.LevelVar)
+	logLevel.Set(level)
+
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
+		Level: logLevel,
 	}))
 
-	server.Execute(ctx, cfg, logger)
+	server.Execute(ctx, cfg, logger, logLevel)
 }
@@ -3,13 +3,28 @@ package server
 import (
 	"context"
 	"github.com/ewik2k21/grpcOrderService/config"
+	"github.com/ewik2k21/grpcOrderService/internal/cache"
+	"github.com/ewik2k21/grpcOrderService/internal/catalog"
+	"github.com/ewik2k21/grpcOrderService/internal/clock"
+	"github.com/ewik2k21/grpcOrderService/internal/events"
+	"github.com/ewik2k21/grpcOrderService/internal/fees"
 	"github.com/ewik2k21/grpcOrderService/internal/handlers"
+	"github.com/ewik2k21/grpcOrderService/internal/idgen"
 	"github.com/ewik2k21/grpcOrderService/internal/interceptors"
+	"github.com/ewik2k21/grpcOrderService/internal/ledger"
+	"github.com/ewik2k21/grpcOrderService/internal/marketdata"
+	"github.com/ewik2k21/grpcOrderService/internal/marketstate"
+	"github.com/ewik2k21/grpcOrderService/internal/orderbook"
+	"github.com/ewik2k21/grpcOrderService/internal/positions"
 	"github.com/ewik2k21/grpcOrderService/internal/repositories"
+	"github.com/ewik2k21/grpcOrderService/internal/resilience"
+	"github.com/ewik2k21/grpcOrderService/internal/risk"
 	"github.com/ewik2k21/grpcOrderService/internal/services"
 	"github.com/ewik2k21/grpcOrderService/internal/tracing"
 	order_service_v1 "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
 	spot_instrument_service_v1 "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
@@ -25,9 +40,20 @@ import (
 	"time"
 )
 
-func Execute(ctx context.Context, cfg *config.Config, logger *slog.Logger) {
+const (
+	configWatchInterval   = 5 * time.Second
+	orderUpdatesBuffer    = 256
+	orderBookBuffer       = 1024
+	positionUpdatesBuffer = 256
+	marketStatesBuffer    = 64
+)
+
+func Execute(ctx context.Context, cfg *config.Config, logger *slog.Logger, logLevel *slog.LevelVar) {
 	wg := sync.WaitGroup{}
 
+	ctx, stopBackground := context.WithCancel(ctx)
+	defer stopBackground()
+
 	//jaeger init
 	tp, err := tracing.InitJaeger(ctx, "OrderService", *cfg)
 	if err != nil {
@@ -36,8 +62,27 @@ func Execute(ctx context.Context, cfg *config.Config, logger *slog.Logger) {
 	}
 	defer tp.Shutdown(ctx)
 
-	//conn for spot instrument client
-	conn, err := grpc.Dial(cfg.SpotInstrument, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	//conn for spot instrument client with timeout, retry and circuit breaker
+	spotBreaker := resilience.NewCircuitBreaker("spot_instrument", resilience.BreakerSettings{
+		FailureThreshold: cfg.SpotClient.Breaker.FailureThreshold,
+		OpenTimeout:      cfg.SpotClient.Breaker.OpenTimeout,
+		HalfOpenMaxCalls: cfg.SpotClient.Breaker.HalfOpenMaxCalls,
+	}, clock.Real{})
+	conn, err := grpc.NewClient(cfg.SpotInstrument,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(
+			interceptors.RetryClientInterceptor(
+				cfg.SpotClient.Retry.MaxAttempts,
+				resilience.Backoff{
+					Initial:    cfg.SpotClient.Retry.InitialBackoff,
+					Max:        cfg.SpotClient.Retry.MaxBackoff,
+					Multiplier: cfg.SpotClient.Retry.Multiplier,
+				},
+				[]string{spot_instrument_service_v1.SpotInstrumentService_ViewMarkets_FullMethodName},
+			),
+			interceptors.CircuitBreakerClientInterceptor(spotBreaker),
+			interceptors.TimeoutClientInterceptor(cfg.SpotClient.Timeout),
+		))
 	if err != nil {
 		logger.Error("failed to connect to spot instrument service", slog.String("error", err.Error()))
 		os.Exit(1)
@@ -46,12 +91,15 @@ func Execute(ctx context.Context, cfg *config.Config, logger *slog.Logger) {
 
 	spotInstrumentClient := spot_instrument_service_v1.NewSpotInstrumentServiceClient(conn)
 
+	rateLimiter := interceptors.NewRateLimiter(cfg.RateLimit.RPS, cfg.RateLimit.Burst)
+
 	grpcServer := grpc.NewServer(
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.ChainUnaryInterceptor(
 			interceptors.RequestIDInterceptor(),
 			interceptors.LoggerRequestInterceptor(logger),
 			interceptors.PrometheusInterceptor(),
+			interceptors.RateLimitInterceptor(rateLimiter),
 			interceptors.UnaryPanicRecoveryInterceptor(logger)))
 
 	//redis client create
@@ -60,7 +108,6 @@ func Execute(ctx context.Context, cfg *config.Config, logger *slog.Logger) {
 		Password: "",
 		DB:       0,
 	})
-	cacheTTL := 1 * time.Minute
 
 	_, err = redisClient.Ping(ctx).Result()
 	if err != nil {
@@ -69,10 +116,59 @@ func Execute(ctx context.Context, cfg *config.Config, logger *slog.Logger) {
 	}
 	logger.Info("Redis connect on ", slog.String("port", cfg.RedisPort))
 
-	orderRepo := repositories.NewOrderRepository(logger)
-	orderService := services.NewOrderService(orderRepo, spotInstrumentClient, logger, redisClient, cacheTTL)
+	orderRepo := repositories.NewOrderRepository(idgen.TimeOrdered{}, clock.Real{}, logger)
+	marketsCache := cache.NewMarketsCache(spotInstrumentClient, redisClient, logger, cfg.Cache.LRUSize, cachePolicy(cfg))
+	marketCatalog := catalog.NewMarketCatalog(marketsCache, logger, cfg.Catalog.SyncInterval)
+	prometheus.MustRegister(marketCatalog)
+	marketCatalog.SyncAll(ctx, false)
+	go marketCatalog.Run(ctx)
+
+	idempotencyRepo := repositories.NewIdempotencyRepository(redisClient, logger)
+	orderBroker := events.NewOrderBroker(logger, orderUpdatesBuffer)
+	tradeRepo := repositories.NewTradeRepository(logger)
+	positionTracker := positions.NewTracker(logger, positionUpdatesBuffer)
+	defaultLimits, roleLimits := riskLimits(cfg)
+	riskEngine := risk.NewEngine(defaultLimits, roleLimits, risk.DefaultChecks()...)
+	feeEngine := fees.NewEngine(feeSchedules(cfg))
+	marketStates := marketstate.NewStore(repositories.NewMarketStateRepository(redisClient, logger), logger, marketStatesBuffer)
+	if err = marketStates.Load(ctx); err != nil {
+		//starting without the halts would reopen halted markets
+		logger.Error("failed to load market states", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	go marketStates.Run(ctx, cfg.MarketStates.SyncInterval)
+
+	orderService := services.NewOrderService(services.OrderServiceDeps{
+		Repo:         orderRepo,
+		Idempotency:  idempotencyRepo,
+		Trades:       tradeRepo,
+		Deadlines:    repositories.NewDeadlineRepository(redisClient, logger),
+		Catalog:      marketCatalog,
+		MarketStates: marketStates,
+		Broker:       orderBroker,
+		Prices:       marketdata.NewPriceStore(),
+		Books:        orderbook.NewManager(logger, orderBookBuffer),
+		Risk:         riskEngine,
+		Ledger:       ledger.NewLedger(logger),
+		Positions:    positionTracker,
+		Fees:         feeEngine,
+		Clock:        clock.Real{},
+		Logger:       logger,
+	}, orderServicePolicy(cfg))
+	go orderService.Run(ctx)
 	orderHandler := handlers.NewOrderHandler(logger, orderService)
 
+	//hot reload of runtime safe settings
+	go config.Watch(ctx, cfg, configWatchInterval, logger, func(newCfg *config.Config) {
+		if level, err := config.ParseLogLevel(newCfg.LogLevel); err == nil {
+			logLevel.Set(level)
+		}
+		rateLimiter.SetLimit(newCfg.RateLimit.RPS, newCfg.RateLimit.Burst)
+		marketsCache.SetPolicy(cachePolicy(newCfg))
+		riskEngine.SetLimits(riskLimits(newCfg))
+		feeEngine.SetSchedules(feeSchedules(newCfg))
+	})
+
 	order_service_v1.RegisterOrderServiceServer(grpcServer, orderHandler)
 
 	lis, err := net.Listen("tcp", cfg.GRPCPort)
@@ -123,6 +219,7 @@ func Execute(ctx context.Context, cfg *config.Config, logger *slog.Logger) {
 
 	<-stop
 	logger.Info("received shutdown signal, start graceful shutdown")
+	stopBackground()
 	//shutdown grpc
 	grpcServer.GracefulStop()
 	logger.Info("grpc server stopped")
@@ -133,7 +230,7 @@ func Execute(ctx context.Context, cfg *config.Config, logger *slog.Logger) {
 	}
 
 	//shutdown metrics server
-	shutdownCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
 		logger.Error("metrics server shutdown failed", slog.String("error", err.Error()))
@@ -142,3 +239,82 @@ func Execute(ctx context.Context, cfg *config.Config, logger *slog.Logger) {
 	wg.Wait()
 	logger.Info("all stopped")
 }
+
+func cachePolicy(cfg *config.Config) cache.Policy {
+	return cache.Policy{
+		TTL:          cfg.Cache.TTL,
+		RefreshAhead: cfg.Cache.RefreshAhead,
+		StaleGrace:   cfg.Cache.StaleGrace,
+	}
+}
+
+func orderServicePolicy(cfg *config.Config) services.OrderServicePolicy {
+	return services.OrderServicePolicy{
+		IdempotencyWindow:       cfg.Idempotency.Window,
+		IdempotencyPendingTTL:   cfg.Idempotency.PendingTTL,
+		MaxBatchSize:            cfg.Batch.MaxSize,
+		CancelOnDisconnectGrace: cfg.CancelOnDisconnect.GracePeriod,
+		DeadMan: services.DeadManPolicy{
+			MaxTimeout:   cfg.CancelAllAfter.MaxTimeout,
+			PollInterval: cfg.CancelAllAfter.PollInterval,
+			LeaderTTL:    cfg.CancelAllAfter.LeaderTTL,
+		},
+	}
+}
+
+func riskLimits(cfg *config.Config) (risk.Limits, map[spot_instrument_service_v1.UserRole]risk.Limits) {
+	convert := func(l config.RiskLimitsConfig) risk.Limits {
+		limits := risk.Limits{
+			MaxOrderQuantity: l.MaxOrderQuantity,
+			MaxOrderNotional: l.MaxOrderNotional,
+			MaxOpenOrders:    l.MaxOpenOrders,
+			PriceBand:        l.PriceBand,
+			Markets:          make(map[uuid.UUID]risk.MarketLimits, len(l.Markets)),
+		}
+		for marketId, market := range l.Markets {
+			//market ids are checked by config.Validate
+			limits.Markets[uuid.MustParse(marketId)] = risk.MarketLimits{
+				MaxOrderQuantity: market.MaxOrderQuantity,
+				MaxOrderNotional: market.MaxOrderNotional,
+			}
+		}
+		return limits
+	}
+
+	roles := make(map[spot_instrument_service_v1.UserRole]risk.Limits, len(cfg.Risk.Roles))
+	for role, limits := range cfg.Risk.Roles {
+		roles[spot_instrument_service_v1.UserRole(spot_instrument_service_v1.UserRole_value[role])] = convert(limits)
+	}
+	return convert(cfg.Risk.Default), roles
+}
+
+func feeSchedules(cfg *config.Config) (fees.Schedule, map[spot_instrument_service_v1.UserRole]fees.Schedule) {
+	convertTiers := func(tiers []config.FeeTierConfig) []fees.Tier {
+		res := make([]fees.Tier, 0, len(tiers))
+		for _, tier := range tiers {
+			res = append(res, fees.Tier{
+				MinVolume: tier.MinVolume,
+				MakerRate: tier.MakerRate,
+				TakerRate: tier.TakerRate,
+			})
+		}
+		return res
+	}
+	convert := func(s config.FeeScheduleConfig) fees.Schedule {
+		schedule := fees.Schedule{
+			Tiers:   convertTiers(s.Tiers),
+			Markets: make(map[uuid.UUID][]fees.Tier, len(s.Markets)),
+		}
+		for marketId, tiers := range s.Markets {
+			//market ids are checked by config.Validate
+			schedule.Markets[uuid.MustParse(marketId)] = convertTiers(tiers)
+		}
+		return schedule
+	}
+
+	roles := make(map[spot_instrument_service_v1.UserRole]fees.Schedule, len(cfg.Fees.Roles))
+	for role, schedule := range cfg.Fees.Roles {
+		roles[spot_instrument_service_v1.UserRole(spot_instrument_service_v1.UserRole_value[role])] = convert(schedule)
+	}
+	return convert(cfg.Fees.Default), roles
+}
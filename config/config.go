@@ -1,63 +1,535 @@
 package config
 
 import (
+	"bytes"
+	"errors"
 	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
 )
 
+const defaultConfigPath = "config/config.yaml"
+
 type Config struct {
-	GRPCPort       string
-	HTTPPort       string
-	SpotInstrument string
-	RedisPort      string
-	JaegerPort     string
-}
-
-func InitConfig() *Config {
-	grpcPort := flag.String("grpcPort", ":50051", "grpcPort to listen on")
-	httpPort := flag.String("httpPort", ":2113", "httpPort for metrics")
-	spotInstrumentAddr := flag.String("spotInstrument", "spot-instrument-service:50052", "Spot instrument address")
-	redisPort := flag.String("redisPort", "redis:6379", "redisPort to redis client")
-	JaegerPort := flag.String("jaegerPort", "jaeger:4318", "port for tracing")
+	GRPCPort           string                   `yaml:"grpc_port"`
+	HTTPPort           string                   `yaml:"http_port"`
+	SpotInstrument     string                   `yaml:"spot_instrument"`
+	RedisPort          string                   `yaml:"redis_port"`
+	JaegerPort         string                   `yaml:"jaeger_port"`
+	LogLevel           string                   `yaml:"log_level"`
+	ShutdownTimeout    time.Duration            `yaml:"shutdown_timeout"`
+	Cache              CacheConfig              `yaml:"cache"`
+	RateLimit          RateLimitConfig          `yaml:"rate_limit"`
+	SpotClient         SpotClientConfig         `yaml:"spot_client"`
+	Catalog            CatalogConfig            `yaml:"catalog"`
+	Idempotency        IdempotencyConfig        `yaml:"idempotency"`
+	Batch              BatchConfig              `yaml:"batch"`
+	CancelOnDisconnect CancelOnDisconnectConfig `yaml:"cancel_on_disconnect"`
+	CancelAllAfter     CancelAllAfterConfig     `yaml:"cancel_all_after"`
+	MarketStates       MarketStatesConfig       `yaml:"market_states"`
+	Risk               RiskConfig               `yaml:"risk"`
+	Fees               FeesConfig               `yaml:"fees"`
+
+	// Path is the file the config was loaded from, empty when no file was used.
+	Path string `yaml:"-"`
+	// flags holds the command line flags that were set explicitly, by name
+	flags map[string]string
+}
+
+type CacheConfig struct {
+	TTL          time.Duration `yaml:"ttl"`
+	RefreshAhead time.Duration `yaml:"refresh_ahead"`
+	StaleGrace   time.Duration `yaml:"stale_grace"`
+	LRUSize      int           `yaml:"lru_size"`
+}
+
+type BatchConfig struct {
+	MaxSize int `yaml:"max_size"`
+}
+
+type CancelOnDisconnectConfig struct {
+	GracePeriod time.Duration `yaml:"grace_period"`
+}
+
+type CancelAllAfterConfig struct {
+	MaxTimeout   time.Duration `yaml:"max_timeout"`
+	PollInterval time.Duration `yaml:"poll_interval"`
+	LeaderTTL    time.Duration `yaml:"leader_ttl"`
+}
+
+// RiskConfig holds the pre-trade risk limits, roles without an entry use Default.
+type RiskConfig struct {
+	Default RiskLimitsConfig `yaml:"default"`
+	// Roles is keyed by common.UserRole name, e.g. USER_ROLE_BASIC
+	Roles map[string]RiskLimitsConfig `yaml:"roles"`
+}
+
+type RiskLimitsConfig struct {
+	MaxOrderQuantity float64 `yaml:"max_order_quantity"`
+	MaxOrderNotional float64 `yaml:"max_order_notional"`
+	MaxOpenOrders    int     `yaml:"max_open_orders"`
+	PriceBand        float64 `yaml:"price_band"`
+	// Markets is keyed by market id
+	Markets map[string]MarketRiskLimitsConfig `yaml:"markets"`
+}
+
+type MarketRiskLimitsConfig struct {
+	MaxOrderQuantity float64 `yaml:"max_order_quantity"`
+	MaxOrderNotional float64 `yaml:"max_order_notional"`
+}
+
+// FeesConfig holds the fee schedules, roles without an entry use Default.
+type FeesConfig struct {
+	Default FeeScheduleConfig `yaml:"default"`
+	// Roles is keyed by common.UserRole name, e.g. USER_ROLE_PROFESSIONAL
+	Roles map[string]FeeScheduleConfig `yaml:"roles"`
+}
+
+type FeeScheduleConfig struct {
+	Tiers []FeeTierConfig `yaml:"tiers"`
+	// Markets is keyed by market id and replaces tiers in that market
+	Markets map[string][]FeeTierConfig `yaml:"markets"`
+}
+
+type FeeTierConfig struct {
+	MinVolume float64 `yaml:"min_volume"`
+	MakerRate float64 `yaml:"maker_rate"`
+	TakerRate float64 `yaml:"taker_rate"`
+}
+
+type IdempotencyConfig struct {
+	Window     time.Duration `yaml:"window"`
+	PendingTTL time.Duration `yaml:"pending_ttl"`
+}
+
+type CatalogConfig struct {
+	SyncInterval time.Duration `yaml:"sync_interval"`
+}
+
+type MarketStatesConfig struct {
+	SyncInterval time.Duration `yaml:"sync_interval"`
+}
+
+type SpotClientConfig struct {
+	Timeout time.Duration `yaml:"timeout"`
+	Retry   RetryConfig   `yaml:"retry"`
+	Breaker BreakerConfig `yaml:"breaker"`
+}
+
+type RetryConfig struct {
+	MaxAttempts    int           `yaml:"max_attempts"`
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	MaxBackoff     time.Duration `yaml:"max_backoff"`
+	Multiplier     float64       `yaml:"multiplier"`
+}
+
+type BreakerConfig struct {
+	FailureThreshold int           `yaml:"failure_threshold"`
+	OpenTimeout      time.Duration `yaml:"open_timeout"`
+	HalfOpenMaxCalls int           `yaml:"half_open_max_calls"`
+}
+
+type RateLimitConfig struct {
+	RPS   float64 `yaml:"rps"`
+	Burst int     `yaml:"burst"`
+}
+
+func Default() *Config {
+	return &Config{
+		GRPCPort:        ":50051",
+		HTTPPort:        ":2113",
+		SpotInstrument:  "spot-instrument-service:50052",
+		RedisPort:       "redis:6379",
+		JaegerPort:      "jaeger:4318",
+		LogLevel:        "info",
+		ShutdownTimeout: 5 * time.Second,
+		Cache: CacheConfig{
+			TTL:          1 * time.Minute,
+			RefreshAhead: 10 * time.Second,
+			StaleGrace:   5 * time.Minute,
+			LRUSize:      64,
+		},
+		RateLimit: RateLimitConfig{
+			RPS:   0,
+			Burst: 0,
+		},
+		Catalog: CatalogConfig{
+			SyncInterval: 15 * time.Second,
+		},
+		Idempotency: IdempotencyConfig{
+			Window:     24 * time.Hour,
+			PendingTTL: 30 * time.Second,
+		},
+		Batch: BatchConfig{
+			MaxSize: 100,
+		},
+		CancelOnDisconnect: CancelOnDisconnectConfig{
+			GracePeriod: 5 * time.Second,
+		},
+		CancelAllAfter: CancelAllAfterConfig{
+			MaxTimeout:   10 * time.Minute,
+			PollInterval: 500 * time.Millisecond,
+			LeaderTTL:    5 * time.Second,
+		},
+		MarketStates: MarketStatesConfig{
+			SyncInterval: time.Second,
+		},
+		SpotClient: SpotClientConfig{
+			Timeout: 2 * time.Second,
+			Retry: RetryConfig{
+				MaxAttempts:    3,
+				InitialBackoff: 100 * time.Millisecond,
+				MaxBackoff:     1 * time.Second,
+				Multiplier:     2,
+			},
+			Breaker: BreakerConfig{
+				FailureThreshold: 5,
+				OpenTimeout:      10 * time.Second,
+				HalfOpenMaxCalls: 1,
+			},
+		},
+	}
+}
+
+// InitConfig builds the config from defaults, then the YAML file, then env, then flags.
+func InitConfig() (*Config, error) {
+	configPath := flag.String("config", "", "path to yaml config file")
+	grpcPort := flag.String("grpcPort", "", "grpcPort to listen on")
+	httpPort := flag.String("httpPort", "", "httpPort for metrics")
+	spotInstrumentAddr := flag.String("spotInstrument", "", "Spot instrument address")
+	redisPort := flag.String("redisPort", "", "redisPort to redis client")
+	jaegerPort := flag.String("jaegerPort", "", "port for tracing")
+	logLevel := flag.String("logLevel", "", "log level: debug, info, warn, error")
 	flag.Parse()
 
-	cfg := &Config{
-		GRPCPort:       *grpcPort,
-		HTTPPort:       *httpPort,
-		SpotInstrument: *spotInstrumentAddr,
-		RedisPort:      *redisPort,
-		JaegerPort:     *JaegerPort,
+	path, required := *configPath, true
+	if path == "" {
+		path, required = os.Getenv("CONFIG_PATH"), true
+	}
+	if path == "" {
+		path, required = defaultConfigPath, false
+	}
+
+	cfg, err := Load(path, required)
+	if err != nil {
+		return nil, err
 	}
 
-	if *grpcPort == ":50051" {
-		if envPort := os.Getenv("GRPC_PORT"); envPort != "" {
-			cfg.GRPCPort = envPort
+	if err = applyEnv(cfg); err != nil {
+		return nil, err
+	}
+
+	//only flags set explicitly override file and env
+	values := map[string]*string{
+		"grpcPort":       grpcPort,
+		"httpPort":       httpPort,
+		"spotInstrument": spotInstrumentAddr,
+		"redisPort":      redisPort,
+		"jaegerPort":     jaegerPort,
+		"logLevel":       logLevel,
+	}
+	cfg.flags = make(map[string]string)
+	flag.Visit(func(f *flag.Flag) {
+		if value, ok := values[f.Name]; ok {
+			cfg.flags[f.Name] = *value
 		}
+	})
+	applyFlags(cfg, cfg.flags)
+
+	if err = cfg.Validate(); err != nil {
+		return nil, err
 	}
 
-	if *httpPort == ":2113" {
-		if envPort := os.Getenv("HTTP_PORT"); envPort != "" {
-			cfg.HTTPPort = envPort
+	return cfg, nil
+}
+
+// Reload reads the file of c again and applies env and the flags c was built with
+// on top, so a reload keeps the order defaults < file < env < flags.
+func (c *Config) Reload() (*Config, error) {
+	cfg, err := Load(c.Path, true)
+	if err != nil {
+		return nil, err
+	}
+	if err = applyEnv(cfg); err != nil {
+		return nil, err
+	}
+	cfg.flags = c.flags
+	applyFlags(cfg, cfg.flags)
+
+	if err = cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func applyFlags(cfg *Config, flags map[string]string) {
+	for name, value := range flags {
+		switch name {
+		case "grpcPort":
+			cfg.GRPCPort = value
+		case "httpPort":
+			cfg.HTTPPort = value
+		case "spotInstrument":
+			cfg.SpotInstrument = value
+		case "redisPort":
+			cfg.RedisPort = value
+		case "jaegerPort":
+			cfg.JaegerPort = value
+		case "logLevel":
+			cfg.LogLevel = value
 		}
 	}
+}
+
+// Load reads the YAML file at path on top of the defaults.
+// A missing file is an error only when required is set.
+func Load(path string, required bool) (*Config, error) {
+	cfg := Default()
 
-	if *spotInstrumentAddr == "spot-instrument-service:50052" {
-		if envPort := os.Getenv("SPOT_INSTRUMENT_ADDR"); envPort != "" {
-			cfg.SpotInstrument = envPort
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) && !required {
+			return cfg, nil
 		}
+		return nil, fmt.Errorf("config: read %s: %w", path, err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("config: parse %s: %w", path, err)
 	}
+	cfg.Path = path
 
-	if *redisPort == "localhost:6379" {
-		if envPort := os.Getenv("REDIS_PORT"); envPort != "" {
-			cfg.RedisPort = envPort
+	return cfg, nil
+}
+
+func applyEnv(cfg *Config) error {
+	var errs []error
+
+	setString := func(key string, dst *string) {
+		if value := os.Getenv(key); value != "" {
+			*dst = value
 		}
 	}
 
-	if *JaegerPort == "jaeger:4318" {
-		if envPort := os.Getenv("JAEGER_AGENT_PORT"); envPort != "" {
-			cfg.JaegerPort = envPort
+	setString("GRPC_PORT", &cfg.GRPCPort)
+	setString("HTTP_PORT", &cfg.HTTPPort)
+	setString("SPOT_INSTRUMENT_ADDR", &cfg.SpotInstrument)
+	setString("REDIS_PORT", &cfg.RedisPort)
+	setString("JAEGER_AGENT_PORT", &cfg.JaegerPort)
+	setString("LOG_LEVEL", &cfg.LogLevel)
+
+	if value := os.Getenv("CACHE_TTL"); value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("CACHE_TTL: %w", err))
+		} else {
+			cfg.Cache.TTL = ttl
 		}
 	}
 
-	return cfg
+	if value := os.Getenv("RATE_LIMIT_RPS"); value != "" {
+		rps, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("RATE_LIMIT_RPS: %w", err))
+		} else {
+			cfg.RateLimit.RPS = rps
+		}
+	}
+
+	if value := os.Getenv("RATE_LIMIT_BURST"); value != "" {
+		burst, err := strconv.Atoi(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("RATE_LIMIT_BURST: %w", err))
+		} else {
+			cfg.RateLimit.Burst = burst
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("config: invalid environment: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Validate reports every invalid field at once so a broken config can be fixed in one go.
+func (c *Config) Validate() error {
+	var errs []error
+
+	checkAddr := func(field, value string) {
+		if _, _, err := net.SplitHostPort(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s: must be host:port, got %q", field, value))
+		}
+	}
+
+	checkAddr("grpc_port", c.GRPCPort)
+	checkAddr("http_port", c.HTTPPort)
+	checkAddr("spot_instrument", c.SpotInstrument)
+	checkAddr("redis_port", c.RedisPort)
+	checkAddr("jaeger_port", c.JaegerPort)
+
+	if _, err := ParseLogLevel(c.LogLevel); err != nil {
+		errs = append(errs, fmt.Errorf("log_level: %w", err))
+	}
+	if c.ShutdownTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("shutdown_timeout: must be positive, got %s", c.ShutdownTimeout))
+	}
+	if c.Cache.TTL <= 0 {
+		errs = append(errs, fmt.Errorf("cache.ttl: must be positive, got %s", c.Cache.TTL))
+	}
+	if c.Cache.RefreshAhead < 0 || c.Cache.RefreshAhead >= c.Cache.TTL {
+		errs = append(errs, fmt.Errorf("cache.refresh_ahead: must be in [0, cache.ttl), got %s", c.Cache.RefreshAhead))
+	}
+	if c.Cache.StaleGrace < 0 {
+		errs = append(errs, fmt.Errorf("cache.stale_grace: must not be negative, got %s", c.Cache.StaleGrace))
+	}
+	if c.Cache.LRUSize <= 0 {
+		errs = append(errs, fmt.Errorf("cache.lru_size: must be positive, got %d", c.Cache.LRUSize))
+	}
+	if c.Catalog.SyncInterval <= 0 {
+		errs = append(errs, fmt.Errorf("catalog.sync_interval: must be positive, got %s", c.Catalog.SyncInterval))
+	}
+	if c.Idempotency.Window <= 0 {
+		errs = append(errs, fmt.Errorf("idempotency.window: must be positive, got %s", c.Idempotency.Window))
+	}
+	if c.Idempotency.PendingTTL <= 0 || c.Idempotency.PendingTTL > c.Idempotency.Window {
+		errs = append(errs, fmt.Errorf("idempotency.pending_ttl: must be positive and at most idempotency.window, got %s", c.Idempotency.PendingTTL))
+	}
+	if c.Batch.MaxSize < 1 {
+		errs = append(errs, fmt.Errorf("batch.max_size: must be at least 1, got %d", c.Batch.MaxSize))
+	}
+	if c.MarketStates.SyncInterval <= 0 {
+		errs = append(errs, fmt.Errorf("market_states.sync_interval: must be positive, got %s", c.MarketStates.SyncInterval))
+	}
+	if c.CancelOnDisconnect.GracePeriod < 0 {
+		errs = append(errs, fmt.Errorf("cancel_on_disconnect.grace_period: must not be negative, got %s", c.CancelOnDisconnect.GracePeriod))
+	}
+	if c.CancelAllAfter.MaxTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("cancel_all_after.max_timeout: must be positive, got %s", c.CancelAllAfter.MaxTimeout))
+	}
+	if c.CancelAllAfter.PollInterval <= 0 {
+		errs = append(errs, fmt.Errorf("cancel_all_after.poll_interval: must be positive, got %s", c.CancelAllAfter.PollInterval))
+	}
+	if c.CancelAllAfter.LeaderTTL <= c.CancelAllAfter.PollInterval {
+		errs = append(errs, fmt.Errorf("cancel_all_after.leader_ttl: must be longer than poll_interval, got %s", c.CancelAllAfter.LeaderTTL))
+	}
+	errs = append(errs, c.Risk.Default.validate("risk.default")...)
+	for role, limits := range c.Risk.Roles {
+		if _, ok := pkg.UserRole_value[role]; !ok {
+			errs = append(errs, fmt.Errorf("risk.roles: unknown user role %q", role))
+		}
+		errs = append(errs, limits.validate("risk.roles."+role)...)
+	}
+	errs = append(errs, c.Fees.Default.validate("fees.default")...)
+	for role, schedule := range c.Fees.Roles {
+		if _, ok := pkg.UserRole_value[role]; !ok {
+			errs = append(errs, fmt.Errorf("fees.roles: unknown user role %q", role))
+		}
+		errs = append(errs, schedule.validate("fees.roles."+role)...)
+	}
+	if c.SpotClient.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("spot_client.timeout: must be positive, got %s", c.SpotClient.Timeout))
+	}
+	if c.SpotClient.Retry.MaxAttempts < 1 {
+		errs = append(errs, fmt.Errorf("spot_client.retry.max_attempts: must be at least 1, got %d", c.SpotClient.Retry.MaxAttempts))
+	}
+	if c.SpotClient.Retry.InitialBackoff < 0 || c.SpotClient.Retry.MaxBackoff < c.SpotClient.Retry.InitialBackoff {
+		errs = append(errs, fmt.Errorf("spot_client.retry: need 0 <= initial_backoff <= max_backoff, got %s and %s",
+			c.SpotClient.Retry.InitialBackoff, c.SpotClient.Retry.MaxBackoff))
+	}
+	if c.SpotClient.Retry.Multiplier < 1 {
+		errs = append(errs, fmt.Errorf("spot_client.retry.multiplier: must be at least 1, got %v", c.SpotClient.Retry.Multiplier))
+	}
+	if c.SpotClient.Breaker.FailureThreshold < 1 {
+		errs = append(errs, fmt.Errorf("spot_client.breaker.failure_threshold: must be at least 1, got %d", c.SpotClient.Breaker.FailureThreshold))
+	}
+	if c.SpotClient.Breaker.OpenTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("spot_client.breaker.open_timeout: must be positive, got %s", c.SpotClient.Breaker.OpenTimeout))
+	}
+	if c.SpotClient.Breaker.HalfOpenMaxCalls < 1 {
+		errs = append(errs, fmt.Errorf("spot_client.breaker.half_open_max_calls: must be at least 1, got %d", c.SpotClient.Breaker.HalfOpenMaxCalls))
+	}
+	if c.RateLimit.RPS < 0 {
+		errs = append(errs, fmt.Errorf("rate_limit.rps: must not be negative, got %v", c.RateLimit.RPS))
+	}
+	if c.RateLimit.Burst < 0 {
+		errs = append(errs, fmt.Errorf("rate_limit.burst: must not be negative, got %d", c.RateLimit.Burst))
+	}
+	if c.RateLimit.RPS > 0 && c.RateLimit.Burst == 0 {
+		errs = append(errs, errors.New("rate_limit.burst: must be set when rate_limit.rps is set"))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("config: invalid configuration:\n%w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func (l RiskLimitsConfig) validate(field string) []error {
+	var errs []error
+	if l.MaxOrderQuantity < 0 || l.MaxOrderNotional < 0 || l.MaxOpenOrders < 0 {
+		errs = append(errs, fmt.Errorf("%s: limits must not be negative", field))
+	}
+	if l.PriceBand < 0 {
+		errs = append(errs, fmt.Errorf("%s.price_band: must not be negative, got %v", field, l.PriceBand))
+	}
+	for marketId, market := range l.Markets {
+		if _, err := uuid.Parse(marketId); err != nil {
+			errs = append(errs, fmt.Errorf("%s.markets: invalid market id %q", field, marketId))
+		}
+		if market.MaxOrderQuantity < 0 || market.MaxOrderNotional < 0 {
+			errs = append(errs, fmt.Errorf("%s.markets.%s: limits must not be negative", field, marketId))
+		}
+	}
+	return errs
+}
+
+func (s FeeScheduleConfig) validate(field string) []error {
+	errs := validateFeeTiers(field+".tiers", s.Tiers)
+	for marketId, tiers := range s.Markets {
+		if _, err := uuid.Parse(marketId); err != nil {
+			errs = append(errs, fmt.Errorf("%s.markets: invalid market id %q", field, marketId))
+		}
+		errs = append(errs, validateFeeTiers(field+".markets."+marketId, tiers)...)
+	}
+	return errs
+}
+
+func validateFeeTiers(field string, tiers []FeeTierConfig) []error {
+	var errs []error
+	for i, tier := range tiers {
+		if tier.MakerRate < 0 || tier.MakerRate >= 1 || tier.TakerRate < 0 || tier.TakerRate >= 1 {
+			errs = append(errs, fmt.Errorf("%s[%d]: rates must be in [0, 1)", field, i))
+		}
+		if i > 0 && tier.MinVolume <= tiers[i-1].MinVolume {
+			errs = append(errs, fmt.Errorf("%s[%d]: min_volume must increase from tier to tier", field, i))
+		}
+	}
+	return errs
+}
+
+func ParseLogLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("unknown level %q, want debug, info, warn or error", level)
+	}
 }
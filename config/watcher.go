@@ -0,0 +1,54 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"time"
+)
+
+// Watch polls the file of cfg and calls onReload with the new config every time the
+// file changes and still passes validation. Env and flag overrides are re-applied on
+// top of the file, only settings that are safe to change at runtime should be read
+// from the reloaded config.
+func Watch(ctx context.Context, cfg *Config, interval time.Duration, logger *slog.Logger, onReload func(*Config)) {
+	path := cfg.Path
+	if path == "" {
+		return
+	}
+
+	var lastMod time.Time
+	if info, err := os.Stat(path); err == nil {
+		lastMod = info.ModTime()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(path)
+			if err != nil {
+				logger.Warn("failed stat config file", slog.String("path", path), slog.String("error", err.Error()))
+				continue
+			}
+			if !info.ModTime().After(lastMod) {
+				continue
+			}
+			lastMod = info.ModTime()
+
+			reloaded, err := cfg.Reload()
+			if err != nil {
+				logger.Error("config reload rejected, keeping previous settings",
+					slog.String("path", path), slog.String("error", err.Error()))
+				continue
+			}
+
+			logger.Info("config reloaded", slog.String("path", path))
+			onReload(reloaded)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/protobuf/proto"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	MarketsCacheRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "markets_cache_requests_total",
+			Help: "markets cache lookups by tier and result",
+		},
+		[]string{"tier", "result"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(MarketsCacheRequests)
+}
+
+// Policy controls how long cached markets are fresh and how long they may be served stale.
+type Policy struct {
+	TTL          time.Duration
+	RefreshAhead time.Duration
+	StaleGrace   time.Duration
+}
+
+// MarketsCache keeps ViewMarkets responses per user role in an in-process LRU
+// in front of Redis. Concurrent loads of the same role are collapsed into a
+// single spot instrument call, entries close to expiry are refreshed in the
+// background and expired entries are still served during the stale grace
+// window when the spot instrument service cannot be reached.
+type MarketsCache struct {
+	client      pkg.SpotInstrumentServiceClient
+	redisClient *redis.Client
+	logger      *slog.Logger
+	group       singleflight.Group
+	// refreshing holds the keys with a background refresh in flight
+	refreshing sync.Map
+
+	ttl          atomic.Int64
+	refreshAhead atomic.Int64
+	staleGrace   atomic.Int64
+
+	mu       sync.Mutex
+	capacity int
+	lru      *list.List
+	items    map[string]*list.Element
+}
+
+type marketsEntry struct {
+	key       string
+	resp      *pkg.ViewMarketsResponse
+	fetchedAt time.Time
+}
+
+type redisMarkets struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Data      []byte    `json:"data"`
+}
+
+func NewMarketsCache(
+	client pkg.SpotInstrumentServiceClient,
+	redisClient *redis.Client,
+	logger *slog.Logger,
+	capacity int,
+	policy Policy,
+) *MarketsCache {
+	c := &MarketsCache{
+		client:      client,
+		redisClient: redisClient,
+		logger:      logger,
+		capacity:    capacity,
+		lru:         list.New(),
+		items:       make(map[string]*list.Element),
+	}
+	c.SetPolicy(policy)
+	return c
+}
+
+// SetPolicy changes the cache timings, used on config reload.
+func (c *MarketsCache) SetPolicy(policy Policy) {
+	c.ttl.Store(int64(policy.TTL))
+	c.refreshAhead.Store(int64(policy.RefreshAhead))
+	c.staleGrace.Store(int64(policy.StaleGrace))
+}
+
+func (c *MarketsCache) policy() Policy {
+	return Policy{
+		TTL:          time.Duration(c.ttl.Load()),
+		RefreshAhead: time.Duration(c.refreshAhead.Load()),
+		StaleGrace:   time.Duration(c.staleGrace.Load()),
+	}
+}
+
+func cacheKey(role pkg.UserRole) string {
+	return fmt.Sprintf("markets:%v", role.String())
+}
+
+// Get returns the markets visible to role.
+func (c *MarketsCache) Get(ctx context.Context, role pkg.UserRole) (*pkg.ViewMarketsResponse, error) {
+	key := cacheKey(role)
+	policy := c.policy()
+
+	entry, ok := c.getLocal(key)
+	if !ok {
+		MarketsCacheRequests.WithLabelValues("local", "miss").Inc()
+		entry, ok = c.getRedis(ctx, key)
+		if ok {
+			MarketsCacheRequests.WithLabelValues("redis", "hit").Inc()
+			c.putLocal(entry)
+		} else {
+			MarketsCacheRequests.WithLabelValues("redis", "miss").Inc()
+		}
+	} else {
+		MarketsCacheRequests.WithLabelValues("local", "hit").Inc()
+	}
+
+	if ok {
+		age := time.Since(entry.fetchedAt)
+		if age < policy.TTL {
+			if age >= policy.TTL-policy.RefreshAhead {
+				c.refreshAsync(role)
+			}
+			return entry.resp, nil
+		}
+	}
+
+	resp, err := c.load(ctx, role)
+	if err != nil {
+		if ok && time.Since(entry.fetchedAt) < policy.TTL+policy.StaleGrace {
+			MarketsCacheRequests.WithLabelValues("stale", "hit").Inc()
+			c.logger.Warn("serving stale markets, spot instrument refresh failed",
+				slog.String("role", role.String()),
+				slog.String("error", err.Error()))
+			return entry.resp, nil
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Refresh reloads the markets of role from the spot instrument service, bypassing both tiers.
+func (c *MarketsCache) Refresh(ctx context.Context, role pkg.UserRole) (*pkg.ViewMarketsResponse, error) {
+	return c.load(ctx, role)
+}
+
+// refreshAsync starts a background refresh of role unless one is running already.
+func (c *MarketsCache) refreshAsync(role pkg.UserRole) {
+	key := cacheKey(role)
+	if _, running := c.refreshing.LoadOrStore(key, struct{}{}); running {
+		return
+	}
+	go func() {
+		defer c.refreshing.Delete(key)
+		ctx, cancel := context.WithTimeout(context.Background(), c.policy().TTL)
+		defer cancel()
+		if _, err := c.load(ctx, role); err != nil {
+			c.logger.Warn("background markets refresh failed",
+				slog.String("role", role.String()),
+				slog.String("error", err.Error()))
+		}
+	}()
+}
+
+// load fetches markets for role once per concurrent group of callers and fills both tiers.
+func (c *MarketsCache) load(ctx context.Context, role pkg.UserRole) (*pkg.ViewMarketsResponse, error) {
+	key := cacheKey(role)
+
+	ch := c.group.DoChan(key, func() (any, error) {
+		//shared load must not be cancelled by the first caller going away, it is
+		//bounded by the ttl instead
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), c.policy().TTL)
+		defer cancel()
+
+		resp, err := c.client.ViewMarkets(loadCtx, &pkg.ViewMarketsRequest{UserRole: role})
+		if err != nil {
+			c.logger.Error("error request view markets from clients", slog.String("error", err.Error()))
+			return nil, err
+		}
+
+		entry := &marketsEntry{key: key, resp: resp, fetchedAt: time.Now()}
+		c.putLocal(entry)
+		c.putRedis(loadCtx, entry)
+		return resp, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*pkg.ViewMarketsResponse), nil
+	}
+}
+
+func (c *MarketsCache) getLocal(key string) (*marketsEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*marketsEntry), true
+}
+
+func (c *MarketsCache) putLocal(entry *marketsEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[entry.key]; ok {
+		//never replace a newer entry with an older one read from redis
+		if elem.Value.(*marketsEntry).fetchedAt.After(entry.fetchedAt) {
+			return
+		}
+		elem.Value = entry
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.items[entry.key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.items, oldest.Value.(*marketsEntry).key)
+	}
+}
+
+func (c *MarketsCache) getRedis(ctx context.Context, key string) (*marketsEntry, bool) {
+	cachedData, err := c.redisClient.Get(ctx, key).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			c.logger.Warn("failed to read markets from redis", slog.String("error", err.Error()))
+		}
+		return nil, false
+	}
+
+	var cached redisMarkets
+	if err = json.Unmarshal(cachedData, &cached); err != nil {
+		return nil, false
+	}
+	var resp pkg.ViewMarketsResponse
+	if err = proto.Unmarshal(cached.Data, &resp); err != nil {
+		return nil, false
+	}
+
+	return &marketsEntry{key: key, resp: &resp, fetchedAt: cached.FetchedAt}, true
+}
+
+func (c *MarketsCache) putRedis(ctx context.Context, entry *marketsEntry) {
+	data, err := proto.Marshal(entry.resp)
+	if err != nil {
+		c.logger.Error("failed to marshal markets", slog.String("error", err.Error()))
+		return
+	}
+	dataBytes, err := json.Marshal(redisMarkets{FetchedAt: entry.fetchedAt, Data: data})
+	if err != nil {
+		c.logger.Error("failed to marshal markets", slog.String("error", err.Error()))
+		return
+	}
+
+	//keep the entry in redis through the grace window so other instances can serve it stale
+	policy := c.policy()
+	if err = c.redisClient.SetEx(ctx, entry.key, dataBytes, policy.TTL+policy.StaleGrace).Err(); err != nil {
+		c.logger.Error("failed to cache data", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,186 @@
+package catalog
+
+import (
+	"context"
+	"github.com/ewik2k21/grpcOrderService/internal/cache"
+	"github.com/ewik2k21/grpcOrderService/internal/mappers"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+var (
+	versionDesc = prometheus.NewDesc(
+		"market_catalog_version",
+		"version of the market catalog per user role, bumped when the markets change",
+		[]string{"role"}, nil,
+	)
+	ageDesc = prometheus.NewDesc(
+		"market_catalog_age_seconds",
+		"seconds since the market catalog of a user role was last synced",
+		[]string{"role"}, nil,
+	)
+)
+
+// Snapshot is an immutable view of the markets visible to one user role.
+type Snapshot struct {
+	Role     pkg.UserRole
+	Version  uint64
+	SyncedAt time.Time
+	Markets  map[uuid.UUID]*models.Market
+}
+
+// MarketCatalog keeps an indexed copy of the spot instrument markets per user role
+// and syncs it in the background, so order validation never waits on the network.
+type MarketCatalog struct {
+	marketsCache *cache.MarketsCache
+	logger       *slog.Logger
+	interval     time.Duration
+
+	mu        sync.RWMutex
+	snapshots map[pkg.UserRole]*Snapshot
+}
+
+func NewMarketCatalog(marketsCache *cache.MarketsCache, logger *slog.Logger, interval time.Duration) *MarketCatalog {
+	return &MarketCatalog{
+		marketsCache: marketsCache,
+		logger:       logger,
+		interval:     interval,
+		snapshots:    make(map[pkg.UserRole]*Snapshot),
+	}
+}
+
+// Roles returns every user role known to the spot instrument api.
+func Roles() []pkg.UserRole {
+	roles := make([]pkg.UserRole, 0, len(pkg.UserRole_value))
+	for _, value := range pkg.UserRole_value {
+		roles = append(roles, pkg.UserRole(value))
+	}
+	slices.Sort(roles)
+	return roles
+}
+
+// Run syncs every role on the configured interval until ctx is done.
+func (c *MarketCatalog) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.SyncAll(ctx, false)
+		}
+	}
+}
+
+// SyncAll syncs every role, failures are logged and the previous snapshot is kept.
+func (c *MarketCatalog) SyncAll(ctx context.Context, force bool) {
+	for _, role := range Roles() {
+		if _, err := c.Sync(ctx, role, force); err != nil {
+			c.logger.Error("failed sync market catalog",
+				slog.String("role", role.String()),
+				slog.String("error", err.Error()))
+		}
+	}
+}
+
+// Sync refreshes the catalog of role. With force the markets cache is bypassed.
+func (c *MarketCatalog) Sync(ctx context.Context, role pkg.UserRole, force bool) (*Snapshot, error) {
+	var resp *pkg.ViewMarketsResponse
+	var err error
+	if force {
+		resp, err = c.marketsCache.Refresh(ctx, role)
+	} else {
+		resp, err = c.marketsCache.Get(ctx, role)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	markets, err := mappers.MapProtoToMarkets(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	indexed := make(map[uuid.UUID]*models.Market, len(markets))
+	for _, market := range markets {
+		indexed[market.ID] = market
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snapshot := &Snapshot{
+		Role:     role,
+		Version:  1,
+		SyncedAt: time.Now(),
+		Markets:  indexed,
+	}
+	if prev, ok := c.snapshots[role]; ok {
+		snapshot.Version = prev.Version
+		if !sameMarkets(prev.Markets, indexed) {
+			snapshot.Version++
+			c.logger.Info("market catalog changed",
+				slog.String("role", role.String()),
+				slog.Uint64("version", snapshot.Version),
+				slog.Int("markets", len(indexed)))
+		}
+	}
+	c.snapshots[role] = snapshot
+
+	return snapshot, nil
+}
+
+// HasMarket reports whether marketId is in the catalog of any role.
+func (c *MarketCatalog) HasMarket(marketId uuid.UUID) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, snapshot := range c.snapshots {
+		if _, ok := snapshot.Markets[marketId]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Snapshot returns the current catalog of role, false until the first successful sync.
+func (c *MarketCatalog) Snapshot(role pkg.UserRole) (*Snapshot, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	snapshot, ok := c.snapshots[role]
+	return snapshot, ok
+}
+
+func (c *MarketCatalog) Describe(ch chan<- *prometheus.Desc) {
+	ch <- versionDesc
+	ch <- ageDesc
+}
+
+func (c *MarketCatalog) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for role, snapshot := range c.snapshots {
+		ch <- prometheus.MustNewConstMetric(versionDesc, prometheus.GaugeValue, float64(snapshot.Version), role.String())
+		ch <- prometheus.MustNewConstMetric(ageDesc, prometheus.GaugeValue, time.Since(snapshot.SyncedAt).Seconds(), role.String())
+	}
+}
+
+func sameMarkets(a, b map[uuid.UUID]*models.Market) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for id, market := range a {
+		other, ok := b[id]
+		if !ok || !market.Equal(other) {
+			return false
+		}
+	}
+	return true
+}
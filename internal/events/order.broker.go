@@ -0,0 +1,95 @@
+package events
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"log/slog"
+	"sync"
+)
+
+var (
+	DroppedSubscribers = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "order_updates_dropped_subscribers_total",
+			Help: "order update subscribers dropped for not keeping up",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(DroppedSubscribers)
+}
+
+// Filter selects the updates a subscriber receives, a zero field matches everything.
+type Filter struct {
+	UserId uuid.UUID
+}
+
+func (f Filter) match(o *models.Order) bool {
+	return f.UserId == uuid.Nil || f.UserId == o.UserId
+}
+
+type subscriber struct {
+	filter Filter
+	ch     chan models.Order
+}
+
+// OrderBroker fans order changes out to stream subscribers. A subscriber that
+// does not keep up is dropped and its channel closed so it can resubscribe.
+type OrderBroker struct {
+	logger     *slog.Logger
+	bufferSize int
+
+	mu          sync.Mutex
+	nextId      uint64
+	subscribers map[uint64]*subscriber
+}
+
+func NewOrderBroker(logger *slog.Logger, bufferSize int) *OrderBroker {
+	return &OrderBroker{
+		logger:      logger,
+		bufferSize:  bufferSize,
+		subscribers: make(map[uint64]*subscriber),
+	}
+}
+
+// Subscribe returns a channel of order snapshots and a func to stop the subscription.
+func (b *OrderBroker) Subscribe(filter Filter) (<-chan models.Order, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextId
+	b.nextId++
+	sub := &subscriber{filter: filter, ch: make(chan models.Order, b.bufferSize)}
+	b.subscribers[id] = sub
+
+	return sub.ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[id]; ok {
+			delete(b.subscribers, id)
+			close(sub.ch)
+		}
+	}
+}
+
+// Publish sends a snapshot of o to every matching subscriber without blocking.
+func (b *OrderBroker) Publish(o *models.Order) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for id, sub := range b.subscribers {
+		if !sub.filter.match(o) {
+			continue
+		}
+		select {
+		case sub.ch <- *o:
+		default:
+			delete(b.subscribers, id)
+			close(sub.ch)
+			DroppedSubscribers.Inc()
+			b.logger.Warn("dropped slow order updates subscriber")
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package fees
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"sync"
+)
+
+var (
+	Collected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fees_collected_total",
+			Help: "trading fees charged on fills per asset and liquidity",
+		},
+		[]string{"asset", "liquidity"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(Collected)
+}
+
+// Tier applies from MinVolume of traded quote notional of a user.
+type Tier struct {
+	MinVolume float64
+	MakerRate float64
+	TakerRate float64
+}
+
+// Schedule holds tiers sorted by MinVolume, Markets override them per market.
+type Schedule struct {
+	Tiers   []Tier
+	Markets map[uuid.UUID][]Tier
+}
+
+func (s Schedule) tiers(marketId uuid.UUID) []Tier {
+	if tiers, ok := s.Markets[marketId]; ok {
+		return tiers
+	}
+	return s.Tiers
+}
+
+// Engine prices fills with maker and taker rates picked by user role, market and
+// the volume the user traded since the service started.
+type Engine struct {
+	mu       sync.RWMutex
+	defaults Schedule
+	roles    map[pkg.UserRole]Schedule
+	volumes  map[uuid.UUID]float64
+}
+
+func NewEngine(defaults Schedule, roles map[pkg.UserRole]Schedule) *Engine {
+	return &Engine{
+		defaults: defaults,
+		roles:    roles,
+		volumes:  make(map[uuid.UUID]float64),
+	}
+}
+
+// SetSchedules replaces the schedules, roles without an entry use defaults.
+func (e *Engine) SetSchedules(defaults Schedule, roles map[pkg.UserRole]Schedule) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.defaults = defaults
+	e.roles = roles
+}
+
+// Rates returns the maker and taker rate of a user in marketId.
+func (e *Engine) Rates(role pkg.UserRole, userId, marketId uuid.UUID) (float64, float64) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.rates(role, userId, marketId)
+}
+
+// rates must be called with e.mu held.
+func (e *Engine) rates(role pkg.UserRole, userId, marketId uuid.UUID) (float64, float64) {
+	schedule, ok := e.roles[role]
+	if !ok {
+		schedule = e.defaults
+	}
+	volume := e.volumes[userId]
+	maker, taker := 0.0, 0.0
+	for _, tier := range schedule.tiers(marketId) {
+		if volume < tier.MinVolume {
+			break
+		}
+		maker, taker = tier.MakerRate, tier.TakerRate
+	}
+	return maker, taker
+}
+
+// Quote sets the fees of trade. Each side pays in the asset it receives: the buyer
+// in base, the seller in quote.
+func (e *Engine) Quote(trade *models.Trade, buyerRole, sellerRole pkg.UserRole, base, quote string) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	buyerMaker, buyerTaker := e.rates(buyerRole, trade.BuyUserId, trade.MarketId)
+	sellerMaker, sellerTaker := e.rates(sellerRole, trade.SellUserId, trade.MarketId)
+	buyerRate, sellerRate := buyerMaker, sellerTaker
+	if trade.TakerSide == order.Side_BUY {
+		buyerRate, sellerRate = buyerTaker, sellerMaker
+	}
+
+	trade.BuyFee = trade.Quantity * buyerRate
+	trade.BuyFeeAsset = base
+	trade.SellFee = trade.Price * trade.Quantity * sellerRate
+	trade.SellFeeAsset = quote
+}
+
+// Record adds a settled trade to the volume of both users and to the fee metrics.
+func (e *Engine) Record(trade *models.Trade) {
+	e.mu.Lock()
+	notional := trade.Price * trade.Quantity
+	e.volumes[trade.BuyUserId] += notional
+	e.volumes[trade.SellUserId] += notional
+	e.mu.Unlock()
+
+	buyLiquidity, sellLiquidity := "maker", "taker"
+	if trade.TakerSide == order.Side_BUY {
+		buyLiquidity, sellLiquidity = "taker", "maker"
+	}
+	Collected.WithLabelValues(trade.BuyFeeAsset, buyLiquidity).Add(trade.BuyFee)
+	Collected.WithLabelValues(trade.SellFeeAsset, sellLiquidity).Add(trade.SellFee)
+}
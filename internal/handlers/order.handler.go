@@ -2,16 +2,22 @@ package handlers
 
 import (
 	"context"
+	"github.com/ewik2k21/grpcOrderService/internal/mappers"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/ewik2k21/grpcOrderService/internal/orderbook"
 	"github.com/ewik2k21/grpcOrderService/internal/services"
 	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"log/slog"
+	"time"
 )
 
 type OrderHandler struct {
 	order.UnimplementedOrderServiceServer
-	service services.OrderService
+	service *services.OrderService
 	logger  *slog.Logger
 }
 
@@ -21,7 +27,7 @@ func NewOrderHandler(
 ) *OrderHandler {
 	return &OrderHandler{
 		logger:  logger,
-		service: *service,
+		service: service,
 	}
 }
 
@@ -32,16 +38,119 @@ func (h *OrderHandler) CreateOrder(ctx context.Context, request *order.CreateOrd
 	span.SetAttributes(attribute.String("user.role", request.GetUserRole().String()))
 	userRole := request.GetUserRole()
 
-	orderId, status, err := h.service.CreateOrder(ctx, userRole, request)
+	idempotencyKey := request.GetIdempotencyKey()
+	if idempotencyKey == "" {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if keys := md.Get("x-idempotency-key"); len(keys) > 0 {
+				idempotencyKey = keys[0]
+			}
+		}
+	}
+
+	created, err := h.service.CreateOrder(ctx, userRole, request, idempotencyKey)
 	if err != nil {
 		return nil, err
 	}
 
 	return &order.CreateOrderResponse{
-		OrderId: orderId,
-		Status:  *status,
+		OrderId:       created.ID.String(),
+		Status:        created.Status,
+		ClientOrderId: request.GetClientOrderId(),
+		StatusReason:  created.StatusReason,
+		StatusDetail:  created.StatusDetail,
+		UpdatedAt:     timestamppb.New(created.UpdatedAt),
+		Version:       created.Version,
+		CreatedAt:     timestamppb.New(created.CreatedAt),
+		TerminalAt:    mappers.MapOptionalTime(created.TerminalAt),
+	}, nil
+
+}
+
+func (h *OrderHandler) ValidateOrder(ctx context.Context, req *order.ValidateOrderRequest) (*order.ValidateOrderResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "ValidateOrder")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.role", req.GetOrder().GetUserRole().String()))
+
+	check, err := h.service.ValidateOrder(req.GetOrder().GetUserRole(), req.GetOrder())
+	if err != nil {
+		return nil, err
+	}
+
+	violations := make([]*order.ItemError, 0, len(check.Violations))
+	for _, violation := range check.Violations {
+		violations = append(violations, mappers.MapErrorToProto(violation))
+	}
+	return &order.ValidateOrderResponse{
+		Valid:        len(violations) == 0,
+		Violations:   violations,
+		MakerRate:    check.MakerRate,
+		TakerRate:    check.TakerRate,
+		EstimatedFee: check.EstimatedFee,
+		FeeAsset:     check.FeeAsset,
 	}, nil
+}
+
+func (h *OrderHandler) GetOrder(ctx context.Context, req *order.GetOrderRequest) (*order.GetOrderResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "GetOrder")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", req.GetUserId()))
+
+	neededOrder, err := h.service.GetOrder(req.GetUserId(), req.GetOrderId(), req.GetClientOrderId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &order.GetOrderResponse{
+		Order: mappers.MapOrderToProto(neededOrder),
+	}, nil
+}
+
+func (h *OrderHandler) ListOrders(ctx context.Context, req *order.ListOrdersRequest) (*order.ListOrdersResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "ListOrders")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", req.GetUserId()),
+		attribute.String("market.id", req.GetMarketId()))
 
+	orders, nextPageToken, err := h.service.ListOrders(req.GetUserId(), req.GetMarketId(), req.Side, req.Status, int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &order.ListOrdersResponse{
+		Orders:        make([]*order.Order, 0, len(orders)),
+		NextPageToken: nextPageToken,
+	}
+	for _, o := range orders {
+		resp.Orders = append(resp.Orders, mappers.MapOrderToProto(o))
+	}
+	return resp, nil
+}
+
+func (h *OrderHandler) CancelOrder(ctx context.Context, req *order.CancelOrderRequest) (*order.CancelOrderResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "CancelOrder")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", req.GetUserId()))
+
+	cancelled, err := h.service.CancelOrder(req.GetUserId(), req.GetOrderId(), req.GetClientOrderId(), req.GetExpectedVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	return &order.CancelOrderResponse{
+		OrderId:      cancelled.ID.String(),
+		Status:       cancelled.Status,
+		Version:      cancelled.Version,
+		StatusReason: cancelled.StatusReason,
+		StatusDetail: cancelled.StatusDetail,
+		UpdatedAt:    timestamppb.New(cancelled.UpdatedAt),
+		CreatedAt:    timestamppb.New(cancelled.CreatedAt),
+		TerminalAt:   mappers.MapOptionalTime(cancelled.TerminalAt),
+	}, nil
 }
 
 func (h *OrderHandler) GetOrderStatus(
@@ -57,13 +166,19 @@ func (h *OrderHandler) GetOrderStatus(
 	userId := req.GetUserId()
 	orderId := req.GetOrderId()
 
-	status, err := h.service.GetOrderStatus(userId, orderId)
+	neededOrder, err := h.service.GetOrderStatus(userId, orderId, req.GetClientOrderId())
 	if err != nil {
 		return nil, err
 	}
 
 	return &order.GetOrderStatusResponse{
-		Status: *status,
+		Status:       neededOrder.Status,
+		Version:      neededOrder.Version,
+		StatusReason: neededOrder.StatusReason,
+		StatusDetail: neededOrder.StatusDetail,
+		CreatedAt:    timestamppb.New(neededOrder.CreatedAt),
+		UpdatedAt:    timestamppb.New(neededOrder.UpdatedAt),
+		TerminalAt:   mappers.MapOptionalTime(neededOrder.TerminalAt),
 	}, nil
 }
 
@@ -77,14 +192,48 @@ func (h *OrderHandler) StreamOrderUpdates(
 
 	span.SetAttributes(attribute.String("user.role", req.GetUserRole().String()))
 
-	update, err := h.service.StreamOrderUpdates(ctx)
+	span.SetAttributes(attribute.Bool("cancel_on_disconnect", req.GetCancelOnDisconnect()))
+
+	return h.service.StreamOrderUpdates(ctx, req.GetUserId(), req.GetCancelOnDisconnect(),
+		func(sessionId string) error {
+			return stream.Send(&order.OrderStatusUpdateResponse{SessionId: sessionId})
+		},
+		func(update models.Order) error {
+			return stream.Send(&order.OrderStatusUpdateResponse{
+				Status: update.Status,
+				Order:  mappers.MapOrderToProto(&update),
+			})
+		})
+}
+
+func (h *OrderHandler) AmendOrder(ctx context.Context, req *order.AmendOrderRequest) (*order.AmendOrderResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "AmendOrder")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.role", req.GetUserRole().String()),
+		attribute.String("order.id", req.GetOrderId()),
+		attribute.Int64("order.expected_version", req.GetExpectedVersion()))
+
+	amended, err := h.service.AmendOrder(req.GetUserRole(), req.GetUserId(), req.GetOrderId(), req.Price, req.Quantity, req.GetExpectedVersion())
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if err = stream.Send(update); err != nil {
-		return err
+	return &order.AmendOrderResponse{
+		Order: mappers.MapOrderToProto(amended),
+	}, nil
+}
+
+func (h *OrderHandler) CloseOrderUpdatesSession(ctx context.Context, req *order.CloseOrderUpdatesSessionRequest) (*order.CloseOrderUpdatesSessionResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "CloseOrderUpdatesSession")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", req.GetUserId()))
+
+	if err := h.service.CloseOrderUpdatesSession(req.GetUserId(), req.GetSessionId()); err != nil {
+		return nil, err
 	}
-	return nil
+	return &order.CloseOrderUpdatesSessionResponse{}, nil
 }
 
 func (h *OrderHandler) UpdateOrderStatus(ctx context.Context, req *order.UpdateOrderStatusRequest) (*order.UpdateOrderStatusResponse, error) {
@@ -95,11 +244,377 @@ func (h *OrderHandler) UpdateOrderStatus(ctx context.Context, req *order.UpdateO
 
 	id, newStatus := req.GetOrderId(), req.GetStatus()
 
-	status, err := h.service.UpdateOrderStatus(id, &newStatus)
+	updated, err := h.service.UpdateOrderStatus(id, &newStatus, req.GetExpectedVersion())
 	if err != nil {
 		return nil, err
 	}
 	return &order.UpdateOrderStatusResponse{
-		Status: *status,
+		Status:       updated.Status,
+		Version:      updated.Version,
+		StatusReason: updated.StatusReason,
+		StatusDetail: updated.StatusDetail,
+		UpdatedAt:    timestamppb.New(updated.UpdatedAt),
+		CreatedAt:    timestamppb.New(updated.CreatedAt),
+		TerminalAt:   mappers.MapOptionalTime(updated.TerminalAt),
+	}, nil
+}
+
+func (h *OrderHandler) RefreshMarketCatalog(ctx context.Context, req *order.RefreshMarketCatalogRequest) (*order.RefreshMarketCatalogResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "RefreshMarketCatalog")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.role", req.GetUserRole().String()))
+
+	snapshots, err := h.service.RefreshMarketCatalog(ctx, req.GetUserRole(), req.GetUserRoles())
+	if err != nil {
+		return nil, err
+	}
+
+	catalogs := make([]*order.MarketCatalogState, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		catalogs = append(catalogs, &order.MarketCatalogState{
+			UserRole:    snapshot.Role,
+			Version:     snapshot.Version,
+			MarketCount: int32(len(snapshot.Markets)),
+			SyncedAt:    timestamppb.New(snapshot.SyncedAt),
+		})
+	}
+
+	return &order.RefreshMarketCatalogResponse{
+		Catalogs: catalogs,
+	}, nil
+}
+
+func (h *OrderHandler) CreateOrders(ctx context.Context, req *order.CreateOrdersRequest) (*order.CreateOrdersResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "CreateOrders")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.role", req.GetUserRole().String()),
+		attribute.Int("batch.size", len(req.GetOrders())))
+
+	results, err := h.service.CreateOrders(ctx, req.GetUserRole(), req.GetUserId(), req.GetOrders(), req.GetMode())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &order.CreateOrdersResponse{
+		Results: make([]*order.CreateOrderResult, 0, len(results)),
+	}
+	for i, result := range results {
+		item := &order.CreateOrderResult{
+			Index:         int32(i),
+			ClientOrderId: req.GetOrders()[i].GetClientOrderId(),
+			Error:         mappers.MapErrorToProto(result.Err),
+		}
+		if result.Err != nil {
+			item.StatusReason = services.RefusalReason(result.Err)
+		}
+		if result.Order != nil {
+			item.OrderId = result.Order.ID.String()
+			item.Status = result.Order.Status
+			item.Version = result.Order.Version
+			item.StatusReason = result.Order.StatusReason
+			item.StatusDetail = result.Order.StatusDetail
+			item.UpdatedAt = timestamppb.New(result.Order.UpdatedAt)
+			item.CreatedAt = timestamppb.New(result.Order.CreatedAt)
+			item.TerminalAt = mappers.MapOptionalTime(result.Order.TerminalAt)
+		}
+		resp.Results = append(resp.Results, item)
+	}
+	return resp, nil
+}
+
+func (h *OrderHandler) CancelOrders(ctx context.Context, req *order.CancelOrdersRequest) (*order.CancelOrdersResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "CancelOrders")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", req.GetUserId()),
+		attribute.Int("batch.size", len(req.GetOrders())))
+
+	results, err := h.service.CancelOrders(ctx, req.GetUserId(), req.GetOrders(), req.GetMode())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &order.CancelOrdersResponse{
+		Results: make([]*order.CancelOrderResult, 0, len(results)),
+	}
+	for i, result := range results {
+		item := &order.CancelOrderResult{
+			Index:   int32(i),
+			OrderId: req.GetOrders()[i].GetOrderId(),
+			Error:   mappers.MapErrorToProto(result.Err),
+		}
+		if result.Order != nil {
+			item.OrderId = result.Order.ID.String()
+			item.Status = result.Order.Status
+			item.Version = result.Order.Version
+			item.StatusReason = result.Order.StatusReason
+			item.StatusDetail = result.Order.StatusDetail
+			item.UpdatedAt = timestamppb.New(result.Order.UpdatedAt)
+			item.CreatedAt = timestamppb.New(result.Order.CreatedAt)
+			item.TerminalAt = mappers.MapOptionalTime(result.Order.TerminalAt)
+		}
+		resp.Results = append(resp.Results, item)
+	}
+	return resp, nil
+}
+
+func (h *OrderHandler) SetReferencePrice(ctx context.Context, req *order.SetReferencePriceRequest) (*order.SetReferencePriceResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "SetReferencePrice")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.role", req.GetUserRole().String()),
+		attribute.String("market.id", req.GetMarketId()))
+
+	triggered, err := h.service.SetReferencePrice(req.GetUserRole(), req.GetMarketId(), req.GetPrice())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &order.SetReferencePriceResponse{
+		TriggeredOrderIds: make([]string, 0, len(triggered)),
+	}
+	for _, orderId := range triggered {
+		resp.TriggeredOrderIds = append(resp.TriggeredOrderIds, orderId.String())
+	}
+	return resp, nil
+}
+
+func (h *OrderHandler) GetOrderBook(ctx context.Context, req *order.GetOrderBookRequest) (*order.GetOrderBookResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "GetOrderBook")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("market.id", req.GetMarketId()))
+
+	snapshot, err := h.service.GetOrderBook(req.GetMarketId(), int(req.GetDepth()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &order.GetOrderBookResponse{
+		MarketId: snapshot.MarketId.String(),
+		Sequence: snapshot.Sequence,
+		Bids:     mappers.MapLevelsToProto(snapshot.Bids),
+		Asks:     mappers.MapLevelsToProto(snapshot.Asks),
+	}, nil
+}
+
+func (h *OrderHandler) StreamOrderBook(
+	req *order.StreamOrderBookRequest,
+	stream order.OrderService_StreamOrderBookServer,
+) error {
+	ctx := stream.Context()
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "StreamOrderBook")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("market.id", req.GetMarketId()))
+
+	return h.service.StreamOrderBook(ctx, req.GetMarketId(),
+		func(snapshot orderbook.Snapshot) error {
+			return stream.Send(mappers.MapSnapshotToProto(snapshot))
+		},
+		func(diff orderbook.Diff) error {
+			return stream.Send(mappers.MapDiffToProto(diff))
+		})
+}
+
+func (h *OrderHandler) ListTrades(ctx context.Context, req *order.ListTradesRequest) (*order.ListTradesResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "ListTrades")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", req.GetUserId()),
+		attribute.String("market.id", req.GetMarketId()))
+
+	trades, nextPageToken, err := h.service.ListTrades(req.GetUserId(), req.GetMarketId(), int(req.GetPageSize()), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	return &order.ListTradesResponse{
+		Trades:        mappers.MapTradesToProto(trades),
+		NextPageToken: nextPageToken,
 	}, nil
 }
+
+func (h *OrderHandler) GetBalances(ctx context.Context, req *order.GetBalancesRequest) (*order.GetBalancesResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "GetBalances")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", req.GetUserId()))
+
+	balances, err := h.service.GetBalances(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	return &order.GetBalancesResponse{Balances: mappers.MapBalancesToProto(balances)}, nil
+}
+
+func (h *OrderHandler) CreditAccount(ctx context.Context, req *order.CreditAccountRequest) (*order.CreditAccountResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "CreditAccount")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.role", req.GetUserRole().String()),
+		attribute.String("user.id", req.GetUserId()))
+
+	balance, err := h.service.CreditAccount(req.GetUserRole(), req.GetUserId(), req.GetAsset(), req.GetAmount(), req.GetReference())
+	if err != nil {
+		return nil, err
+	}
+	return &order.CreditAccountResponse{Balance: mappers.MapBalanceToProto(balance)}, nil
+}
+
+func (h *OrderHandler) DebitAccount(ctx context.Context, req *order.DebitAccountRequest) (*order.DebitAccountResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "DebitAccount")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.role", req.GetUserRole().String()),
+		attribute.String("user.id", req.GetUserId()))
+
+	balance, err := h.service.DebitAccount(req.GetUserRole(), req.GetUserId(), req.GetAsset(), req.GetAmount(), req.GetReference())
+	if err != nil {
+		return nil, err
+	}
+	return &order.DebitAccountResponse{Balance: mappers.MapBalanceToProto(balance)}, nil
+}
+
+func (h *OrderHandler) GetPositions(ctx context.Context, req *order.GetPositionsRequest) (*order.GetPositionsResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "GetPositions")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", req.GetUserId()))
+
+	positions, err := h.service.GetPositions(req.GetUserId(), req.GetMarketId())
+	if err != nil {
+		return nil, err
+	}
+	return &order.GetPositionsResponse{Positions: mappers.MapPositionsToProto(positions)}, nil
+}
+
+func (h *OrderHandler) StreamPositions(
+	req *order.StreamPositionsRequest,
+	stream order.OrderService_StreamPositionsServer,
+) error {
+	ctx := stream.Context()
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "StreamPositions")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("user.id", req.GetUserId()))
+
+	return h.service.StreamPositions(ctx, req.GetUserId(), func(position models.Position, snapshot bool) error {
+		return stream.Send(&order.PositionUpdate{
+			Position: mappers.MapPositionToProto(position),
+			Snapshot: snapshot,
+		})
+	})
+}
+
+func (h *OrderHandler) HaltMarket(ctx context.Context, req *order.HaltMarketRequest) (*order.HaltMarketResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "HaltMarket")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.role", req.GetUserRole().String()),
+		attribute.String("market.id", req.GetMarketId()))
+
+	state, err := h.service.HaltMarket(ctx, req.GetUserRole(), req.GetMarketId(), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	return &order.HaltMarketResponse{State: mappers.MapMarketStateToProto(state)}, nil
+}
+
+func (h *OrderHandler) ResumeMarket(ctx context.Context, req *order.ResumeMarketRequest) (*order.ResumeMarketResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "ResumeMarket")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.role", req.GetUserRole().String()),
+		attribute.String("market.id", req.GetMarketId()))
+
+	state, err := h.service.ResumeMarket(ctx, req.GetUserRole(), req.GetMarketId(), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	return &order.ResumeMarketResponse{State: mappers.MapMarketStateToProto(state)}, nil
+}
+
+func (h *OrderHandler) SetMarketCancelOnly(ctx context.Context, req *order.SetMarketCancelOnlyRequest) (*order.SetMarketCancelOnlyResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "SetMarketCancelOnly")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.role", req.GetUserRole().String()),
+		attribute.String("market.id", req.GetMarketId()))
+
+	state, err := h.service.SetMarketCancelOnly(ctx, req.GetUserRole(), req.GetMarketId(), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	return &order.SetMarketCancelOnlyResponse{State: mappers.MapMarketStateToProto(state)}, nil
+}
+
+func (h *OrderHandler) StreamMarketStates(
+	req *order.StreamMarketStatesRequest,
+	stream order.OrderService_StreamMarketStatesServer,
+) error {
+	ctx := stream.Context()
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "StreamMarketStates")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("market.id", req.GetMarketId()))
+
+	return h.service.StreamMarketStates(ctx, req.GetMarketId(), func(state models.MarketState) error {
+		return stream.Send(mappers.MapMarketStateToProto(state))
+	})
+}
+
+func (h *OrderHandler) MassCancel(ctx context.Context, req *order.MassCancelRequest) (*order.MassCancelResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "MassCancel")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.role", req.GetUserRole().String()),
+		attribute.String("user.id", req.GetUserId()),
+		attribute.String("market.id", req.GetMarketId()))
+
+	cancelled, err := h.service.MassCancel(req.GetUserRole(), req.GetUserId(), req.GetMarketId(), req.Side, req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(cancelled))
+	for _, o := range cancelled {
+		ids = append(ids, o.ID.String())
+	}
+	return &order.MassCancelResponse{
+		CancelledOrderIds: ids,
+		Count:             int32(len(ids)),
+	}, nil
+}
+
+func (h *OrderHandler) CancelAllAfter(ctx context.Context, req *order.CancelAllAfterRequest) (*order.CancelAllAfterResponse, error) {
+	ctx, span := otel.Tracer("OrderService").Start(ctx, "CancelAllAfter")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("user.id", req.GetUserId()),
+		attribute.Int64("timeout_ms", req.GetTimeoutMs()))
+
+	cancelAt, err := h.service.CancelAllAfter(ctx, req.GetUserId(), time.Duration(req.GetTimeoutMs())*time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &order.CancelAllAfterResponse{}
+	if !cancelAt.IsZero() {
+		response.CancelAt = timestamppb.New(cancelAt)
+	}
+	return response, nil
+}
@@ -0,0 +1,17 @@
+package idgen
+
+import "github.com/google/uuid"
+
+// Generator hands out entity ids.
+type Generator interface {
+	NewID() uuid.UUID
+}
+
+// TimeOrdered generates UUIDv7 ids: they start with the unix milliseconds of their
+// creation and grow monotonically within a process, so they sort by creation time
+// and keep index locality in a persistent store. They parse like any other uuid.
+type TimeOrdered struct{}
+
+func (TimeOrdered) NewID() uuid.UUID {
+	return uuid.Must(uuid.NewV7())
+}
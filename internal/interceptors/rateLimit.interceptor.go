@@ -0,0 +1,44 @@
+package interceptors
+
+import (
+	"context"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimiter is a process wide token bucket whose limits can be changed at runtime.
+type RateLimiter struct {
+	limiter *rate.Limiter
+}
+
+// NewRateLimiter creates a limiter, rps <= 0 disables limiting.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	l := &RateLimiter{limiter: rate.NewLimiter(rate.Inf, 0)}
+	l.SetLimit(rps, burst)
+	return l
+}
+
+func (l *RateLimiter) SetLimit(rps float64, burst int) {
+	if rps <= 0 {
+		l.limiter.SetLimit(rate.Inf)
+		return
+	}
+	l.limiter.SetLimit(rate.Limit(rps))
+	l.limiter.SetBurst(burst)
+}
+
+func RateLimitInterceptor(limiter *RateLimiter) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (resp any, err error) {
+		if !limiter.limiter.Allow() {
+			return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s", info.FullMethod)
+		}
+		return handler(ctx, req)
+	}
+}
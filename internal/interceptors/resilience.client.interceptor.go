@@ -0,0 +1,93 @@
+package interceptors
+
+import (
+	"context"
+	"github.com/ewik2k21/grpcOrderService/internal/resilience"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"time"
+)
+
+// ErrCircuitOpen is returned without calling the server while the breaker is open.
+var ErrCircuitOpen = status.Error(codes.Unavailable, "spot instrument service unavailable: circuit breaker open")
+
+// TimeoutClientInterceptor bounds every call attempt by timeout unless the caller set an earlier deadline.
+func TimeoutClientInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// RetryClientInterceptor retries transient failures of idempotent methods with backoff and jitter.
+func RetryClientInterceptor(maxAttempts int, backoff resilience.Backoff, idempotentMethods []string) grpc.UnaryClientInterceptor {
+	idempotent := make(map[string]struct{}, len(idempotentMethods))
+	for _, method := range idempotentMethods {
+		idempotent[method] = struct{}{}
+	}
+
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if _, ok := idempotent[method]; !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		var err error
+		for attempt := 1; attempt <= maxAttempts; attempt++ {
+			err = invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || err == ErrCircuitOpen || !isTransient(err) || attempt == maxAttempts {
+				return err
+			}
+
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(backoff.Delay(attempt)):
+			}
+		}
+		return err
+	}
+}
+
+// CircuitBreakerClientInterceptor fails fast with ErrCircuitOpen while the breaker is open.
+func CircuitBreakerClientInterceptor(breaker *resilience.CircuitBreaker) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if !breaker.Allow() {
+			return ErrCircuitOpen
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		breaker.Done(err == nil || !isTransient(err))
+		return err
+	}
+}
+
+func isTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
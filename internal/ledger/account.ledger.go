@@ -0,0 +1,363 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/google/uuid"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	ErrInvalidAmount     = errors.New("amount must be positive")
+	ErrReservationExists = errors.New("order already holds a reservation")
+)
+
+type AccountKind int
+
+const (
+	Available AccountKind = iota
+	Reserved
+	// External is the counterpart of deposits and withdrawals, it may go negative
+	External
+	// Fees collects the trading fees of an asset
+	Fees
+)
+
+func (k AccountKind) String() string {
+	switch k {
+	case Available:
+		return "available"
+	case Reserved:
+		return "reserved"
+	case Fees:
+		return "fees"
+	default:
+		return "external"
+	}
+}
+
+type Account struct {
+	UserId uuid.UUID
+	Asset  string
+	Kind   AccountKind
+}
+
+func (a Account) String() string {
+	return fmt.Sprintf("%s:%s:%s", a.UserId, a.Asset, a.Kind)
+}
+
+func externalAccount(asset string) Account {
+	return Account{Asset: asset, Kind: External}
+}
+
+func feesAccount(asset string) Account {
+	return Account{Asset: asset, Kind: Fees}
+}
+
+// Posting moves Amount from one account to another, so every posting is both a
+// debit and a credit and the sum of all balances of an asset stays zero.
+type Posting struct {
+	From   Account
+	To     Account
+	Amount float64
+}
+
+// Entry is a set of postings applied together.
+type Entry struct {
+	ID        uuid.UUID
+	Reference string
+	At        time.Time
+	Postings  []Posting
+}
+
+type Balance struct {
+	Asset     string
+	Available float64
+	Reserved  float64
+}
+
+// reservation tracks the funds an open order still holds in its reserved account.
+type reservation struct {
+	account Account
+	amount  float64
+}
+
+// Ledger keeps per user and asset balances as double-entry postings and the
+// reservations of open orders.
+type Ledger struct {
+	logger *slog.Logger
+
+	mu           sync.Mutex
+	balances     map[Account]float64
+	reservations map[uuid.UUID]*reservation
+	journal      []Entry
+}
+
+func NewLedger(logger *slog.Logger) *Ledger {
+	return &Ledger{
+		logger:       logger,
+		balances:     make(map[Account]float64),
+		reservations: make(map[uuid.UUID]*reservation),
+	}
+}
+
+// NormalizeAsset returns the canonical form of an asset code.
+func NormalizeAsset(asset string) string {
+	return strings.ToUpper(strings.TrimSpace(asset))
+}
+
+// Credit deposits amount into the available balance of userId.
+func (l *Ledger) Credit(userId uuid.UUID, asset string, amount float64, reference string) (Balance, error) {
+	if amount <= 0 {
+		return Balance{}, ErrInvalidAmount
+	}
+	asset = NormalizeAsset(asset)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	err := l.post(reference, Posting{
+		From:   externalAccount(asset),
+		To:     Account{UserId: userId, Asset: asset, Kind: Available},
+		Amount: amount,
+	})
+	return l.balance(userId, asset), err
+}
+
+// Debit withdraws amount from the available balance of userId.
+func (l *Ledger) Debit(userId uuid.UUID, asset string, amount float64, reference string) (Balance, error) {
+	if amount <= 0 {
+		return Balance{}, ErrInvalidAmount
+	}
+	asset = NormalizeAsset(asset)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	err := l.post(reference, Posting{
+		From:   Account{UserId: userId, Asset: asset, Kind: Available},
+		To:     externalAccount(asset),
+		Amount: amount,
+	})
+	return l.balance(userId, asset), err
+}
+
+// Reserve moves amount of asset from available to reserved for orderId.
+func (l *Ledger) Reserve(orderId, userId uuid.UUID, asset string, amount float64) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.reservations[orderId]; ok {
+		return ErrReservationExists
+	}
+
+	reserved := Account{UserId: userId, Asset: asset, Kind: Reserved}
+	if err := l.post("reserve "+orderId.String(), Posting{
+		From:   Account{UserId: userId, Asset: asset, Kind: Available},
+		To:     reserved,
+		Amount: amount,
+	}); err != nil {
+		return err
+	}
+	l.reservations[orderId] = &reservation{account: reserved, amount: amount}
+	return nil
+}
+
+// Resize sets what orderId holds to amount and moves the difference between the
+// reserved and the available balance. Orders without a reservation are left alone.
+func (l *Ledger) Resize(orderId uuid.UUID, amount float64) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	res, ok := l.reservations[orderId]
+	if !ok {
+		return nil
+	}
+
+	available := res.account
+	available.Kind = Available
+	var posting Posting
+	switch diff := amount - res.amount; {
+	case diff > models.QuantityEpsilon:
+		posting = Posting{From: available, To: res.account, Amount: diff}
+	case diff < -models.QuantityEpsilon:
+		posting = Posting{From: res.account, To: available, Amount: -diff}
+	default:
+		return nil
+	}
+	if err := l.post("resize "+orderId.String(), posting); err != nil {
+		return err
+	}
+	res.amount = amount
+	return nil
+}
+
+// Release returns what orderId still holds to the available balance.
+func (l *Ledger) Release(orderId uuid.UUID) float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	res, ok := l.reservations[orderId]
+	if !ok {
+		return 0
+	}
+	delete(l.reservations, orderId)
+	if res.amount <= models.QuantityEpsilon {
+		return 0
+	}
+
+	available := res.account
+	available.Kind = Available
+	if err := l.post("release "+orderId.String(), Posting{From: res.account, To: available, Amount: res.amount}); err != nil {
+		l.logger.Error("failed release reservation",
+			slog.String("order_id", orderId.String()),
+			slog.String("error", err.Error()))
+		return 0
+	}
+	return res.amount
+}
+
+// Settle exchanges the assets of a trade between buyer and seller and collects the
+// fees of the trade from what each side receives. Each side pays from the reservation
+// of its order first and from its available balance for the rest. Nothing is posted
+// unless both sides can pay.
+func (l *Ledger) Settle(trade *models.Trade, base, quote string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cost := trade.Price * trade.Quantity
+	buyer := l.pay(trade.BuyOrderId, trade.BuyUserId, quote, cost, Account{UserId: trade.SellUserId, Asset: quote, Kind: Available})
+	seller := l.pay(trade.SellOrderId, trade.SellUserId, base, trade.Quantity, Account{UserId: trade.BuyUserId, Asset: base, Kind: Available})
+
+	postings := append(buyer.postings, seller.postings...)
+	if trade.BuyFee > 0 {
+		postings = append(postings, Posting{
+			From:   Account{UserId: trade.BuyUserId, Asset: base, Kind: Available},
+			To:     feesAccount(base),
+			Amount: trade.BuyFee,
+		})
+	}
+	if trade.SellFee > 0 {
+		postings = append(postings, Posting{
+			From:   Account{UserId: trade.SellUserId, Asset: quote, Kind: Available},
+			To:     feesAccount(quote),
+			Amount: trade.SellFee,
+		})
+	}
+	if err := l.post("trade "+trade.ID.String(), postings...); err != nil {
+		return err
+	}
+	buyer.commit()
+	seller.commit()
+	return nil
+}
+
+type payment struct {
+	postings []Posting
+	commit   func()
+}
+
+// pay must be called with l.mu held, it plans the postings paying amount to
+// counterparty and returns them with the reservation update to apply once posted.
+func (l *Ledger) pay(orderId, userId uuid.UUID, asset string, amount float64, counterparty Account) payment {
+	fromReserved := 0.0
+	res, ok := l.reservations[orderId]
+	if ok {
+		fromReserved = min(amount, res.amount)
+	}
+
+	postings := make([]Posting, 0, 2)
+	if fromReserved > 0 {
+		postings = append(postings, Posting{From: res.account, To: counterparty, Amount: fromReserved})
+	}
+	if rest := amount - fromReserved; rest > models.QuantityEpsilon {
+		postings = append(postings, Posting{
+			From:   Account{UserId: userId, Asset: asset, Kind: Available},
+			To:     counterparty,
+			Amount: rest,
+		})
+	}
+	return payment{
+		postings: postings,
+		commit: func() {
+			if ok {
+				res.amount -= fromReserved
+			}
+		},
+	}
+}
+
+// post must be called with l.mu held, it applies all postings or none.
+func (l *Ledger) post(reference string, postings ...Posting) error {
+	next := make(map[Account]float64)
+	for _, posting := range postings {
+		if posting.Amount <= 0 {
+			return ErrInvalidAmount
+		}
+		if _, ok := next[posting.From]; !ok {
+			next[posting.From] = l.balances[posting.From]
+		}
+		if _, ok := next[posting.To]; !ok {
+			next[posting.To] = l.balances[posting.To]
+		}
+		next[posting.From] -= posting.Amount
+		next[posting.To] += posting.Amount
+	}
+	for account, balance := range next {
+		if account.Kind != External && balance < -models.QuantityEpsilon {
+			return fmt.Errorf("%w in %s", ErrInsufficientFunds, account)
+		}
+	}
+
+	for account, balance := range next {
+		l.balances[account] = balance
+	}
+	l.journal = append(l.journal, Entry{
+		ID:        uuid.New(),
+		Reference: reference,
+		At:        time.Now(),
+		Postings:  postings,
+	})
+	return nil
+}
+
+// Balances returns every asset balance of userId sorted by asset.
+func (l *Ledger) Balances(userId uuid.UUID) []Balance {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	assets := make([]string, 0)
+	for account := range l.balances {
+		if account.UserId == userId && (account.Kind == Available || account.Kind == Reserved) && !slices.Contains(assets, account.Asset) {
+			assets = append(assets, account.Asset)
+		}
+	}
+	slices.Sort(assets)
+
+	balances := make([]Balance, 0, len(assets))
+	for _, asset := range assets {
+		balances = append(balances, l.balance(userId, asset))
+	}
+	return balances
+}
+
+// Balance returns the balance of userId in asset.
+func (l *Ledger) Balance(userId uuid.UUID, asset string) Balance {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.balance(userId, NormalizeAsset(asset))
+}
+
+// balance must be called with l.mu held.
+func (l *Ledger) balance(userId uuid.UUID, asset string) Balance {
+	return Balance{
+		Asset:     asset,
+		Available: l.balances[Account{UserId: userId, Asset: asset, Kind: Available}],
+		Reserved:  l.balances[Account{UserId: userId, Asset: asset, Kind: Reserved}],
+	}
+}
@@ -0,0 +1,205 @@
+package ledger
+
+import (
+	"errors"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"math"
+	"testing"
+)
+
+func newTestLedger() *Ledger {
+	return NewLedger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func assertBalance(t *testing.T, l *Ledger, userId uuid.UUID, asset string, available, reserved float64) {
+	t.Helper()
+	balance := l.Balance(userId, asset)
+	if math.Abs(balance.Available-available) > models.QuantityEpsilon || math.Abs(balance.Reserved-reserved) > models.QuantityEpsilon {
+		t.Fatalf("%s balance %+v, want available %v reserved %v", asset, balance, available, reserved)
+	}
+}
+
+// assertZeroSum checks that the postings of the journal cancel out per asset.
+func assertZeroSum(t *testing.T, l *Ledger) {
+	t.Helper()
+	sums := make(map[string]float64)
+	for account, balance := range l.balances {
+		sums[account.Asset] += balance
+	}
+	for asset, sum := range sums {
+		if math.Abs(sum) > models.QuantityEpsilon {
+			t.Fatalf("balances of %s sum to %v", asset, sum)
+		}
+	}
+}
+
+func TestReserveAndRelease(t *testing.T) {
+	l := newTestLedger()
+	userId, orderId := uuid.New(), uuid.New()
+	if _, err := l.Credit(userId, "usdt", 100, "deposit"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.Reserve(orderId, userId, "USDT", 150); !errors.Is(err, ErrInsufficientFunds) {
+		t.Fatalf("reserve over the balance: %v, want %v", err, ErrInsufficientFunds)
+	}
+	assertBalance(t, l, userId, "USDT", 100, 0)
+
+	if err := l.Reserve(orderId, userId, "USDT", 60); err != nil {
+		t.Fatal(err)
+	}
+	assertBalance(t, l, userId, "USDT", 40, 60)
+	if err := l.Reserve(orderId, userId, "USDT", 10); !errors.Is(err, ErrReservationExists) {
+		t.Fatalf("second reserve: %v, want %v", err, ErrReservationExists)
+	}
+
+	if released := l.Release(orderId); released != 60 {
+		t.Fatalf("released %v, want 60", released)
+	}
+	assertBalance(t, l, userId, "USDT", 100, 0)
+	if released := l.Release(orderId); released != 0 {
+		t.Fatalf("second release returned %v", released)
+	}
+	assertBalance(t, l, userId, "USDT", 100, 0)
+	assertZeroSum(t, l)
+}
+
+func TestSettle(t *testing.T) {
+	tests := []struct {
+		name string
+		// deposits and reservations before the trade
+		buyerQuote, buyReserved     float64
+		sellerBase, sellReserved    float64
+		price, quantity             float64
+		buyFee, sellFee             float64
+		wantErr                     error
+		wantBuyer, wantSeller       Balance
+		wantBuyerBase, wantQuote    float64
+		wantFeesBase, wantFeesQuote float64
+	}{
+		{
+			name:       "paid from reservations",
+			buyerQuote: 100, buyReserved: 100,
+			sellerBase: 2, sellReserved: 2,
+			price: 50, quantity: 2,
+			wantBuyer:     Balance{Available: 0, Reserved: 0},
+			wantSeller:    Balance{Available: 0, Reserved: 0},
+			wantBuyerBase: 2, wantQuote: 100,
+		},
+		{
+			name:       "partial fill keeps the rest reserved",
+			buyerQuote: 100, buyReserved: 100,
+			sellerBase: 2, sellReserved: 2,
+			price: 50, quantity: 1,
+			wantBuyer:     Balance{Available: 0, Reserved: 50},
+			wantSeller:    Balance{Available: 0, Reserved: 1},
+			wantBuyerBase: 1, wantQuote: 50,
+		},
+		{
+			name:       "rest paid from available",
+			buyerQuote: 120, buyReserved: 90,
+			sellerBase: 3, sellReserved: 0,
+			price: 50, quantity: 2,
+			wantBuyer:     Balance{Available: 20, Reserved: 0},
+			wantSeller:    Balance{Available: 1, Reserved: 0},
+			wantBuyerBase: 2, wantQuote: 100,
+		},
+		{
+			name:       "fees collected from what each side receives",
+			buyerQuote: 100, buyReserved: 100,
+			sellerBase: 2, sellReserved: 2,
+			price: 50, quantity: 2,
+			buyFee: 0.002, sellFee: 0.1,
+			wantBuyer:     Balance{Available: 0, Reserved: 0},
+			wantSeller:    Balance{Available: 0, Reserved: 0},
+			wantBuyerBase: 1.998, wantQuote: 99.9,
+			wantFeesBase: 0.002, wantFeesQuote: 0.1,
+		},
+		{
+			name:       "nothing posted when a side can not pay",
+			buyerQuote: 100, buyReserved: 100,
+			sellerBase: 1, sellReserved: 1,
+			price: 50, quantity: 2,
+			wantErr:    ErrInsufficientFunds,
+			wantBuyer:  Balance{Available: 0, Reserved: 100},
+			wantSeller: Balance{Available: 0, Reserved: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newTestLedger()
+			buyer, seller := uuid.New(), uuid.New()
+			trade := &models.Trade{
+				ID:          uuid.New(),
+				BuyOrderId:  uuid.New(),
+				SellOrderId: uuid.New(),
+				BuyUserId:   buyer,
+				SellUserId:  seller,
+				Price:       tt.price,
+				Quantity:    tt.quantity,
+				BuyFee:      tt.buyFee,
+				SellFee:     tt.sellFee,
+			}
+			if _, err := l.Credit(buyer, "USDT", tt.buyerQuote, "deposit"); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := l.Credit(seller, "BTC", tt.sellerBase, "deposit"); err != nil {
+				t.Fatal(err)
+			}
+			if tt.buyReserved > 0 {
+				if err := l.Reserve(trade.BuyOrderId, buyer, "USDT", tt.buyReserved); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.sellReserved > 0 {
+				if err := l.Reserve(trade.SellOrderId, seller, "BTC", tt.sellReserved); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := l.Settle(trade, "BTC", "USDT"); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("settle: %v, want %v", err, tt.wantErr)
+			}
+			assertBalance(t, l, buyer, "USDT", tt.wantBuyer.Available, tt.wantBuyer.Reserved)
+			assertBalance(t, l, seller, "BTC", tt.wantSeller.Available, tt.wantSeller.Reserved)
+			assertBalance(t, l, buyer, "BTC", tt.wantBuyerBase, 0)
+			assertBalance(t, l, seller, "USDT", tt.wantQuote, 0)
+			if fees := l.balances[feesAccount("BTC")]; math.Abs(fees-tt.wantFeesBase) > models.QuantityEpsilon {
+				t.Errorf("BTC fees %v, want %v", fees, tt.wantFeesBase)
+			}
+			if fees := l.balances[feesAccount("USDT")]; math.Abs(fees-tt.wantFeesQuote) > models.QuantityEpsilon {
+				t.Errorf("USDT fees %v, want %v", fees, tt.wantFeesQuote)
+			}
+			assertZeroSum(t, l)
+		})
+	}
+}
+
+func TestReleaseAfterPartialSettle(t *testing.T) {
+	l := newTestLedger()
+	buyer, seller := uuid.New(), uuid.New()
+	buyOrder := uuid.New()
+	if _, err := l.Credit(buyer, "USDT", 100, "deposit"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Credit(seller, "BTC", 1, "deposit"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Reserve(buyOrder, buyer, "USDT", 100); err != nil {
+		t.Fatal(err)
+	}
+	trade := &models.Trade{ID: uuid.New(), BuyOrderId: buyOrder, SellOrderId: uuid.New(), BuyUserId: buyer, SellUserId: seller, Price: 40, Quantity: 1}
+	if err := l.Settle(trade, "BTC", "USDT"); err != nil {
+		t.Fatal(err)
+	}
+
+	//only what the order still holds goes back
+	if released := l.Release(buyOrder); released != 60 {
+		t.Fatalf("released %v, want 60", released)
+	}
+	assertBalance(t, l, buyer, "USDT", 60, 0)
+	assertZeroSum(t, l)
+}
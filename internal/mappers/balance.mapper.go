@@ -0,0 +1,22 @@
+package mappers
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/ledger"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+)
+
+func MapBalanceToProto(balance ledger.Balance) *order.Balance {
+	return &order.Balance{
+		Asset:     balance.Asset,
+		Available: balance.Available,
+		Reserved:  balance.Reserved,
+	}
+}
+
+func MapBalancesToProto(balances []ledger.Balance) []*order.Balance {
+	res := make([]*order.Balance, 0, len(balances))
+	for _, balance := range balances {
+		res = append(res, MapBalanceToProto(balance))
+	}
+	return res
+}
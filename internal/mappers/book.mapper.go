@@ -0,0 +1,47 @@
+package mappers
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/orderbook"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+)
+
+func MapLevelsToProto(levels []orderbook.Level) []*order.PriceLevel {
+	res := make([]*order.PriceLevel, 0, len(levels))
+	for _, level := range levels {
+		res = append(res, MapLevelToProto(level))
+	}
+	return res
+}
+
+func MapLevelToProto(level orderbook.Level) *order.PriceLevel {
+	return &order.PriceLevel{
+		Price:      level.Price,
+		Quantity:   level.Quantity,
+		OrderCount: int32(level.OrderCount),
+	}
+}
+
+func MapSnapshotToProto(snapshot orderbook.Snapshot) *order.OrderBookUpdate {
+	return &order.OrderBookUpdate{
+		MarketId: snapshot.MarketId.String(),
+		Sequence: snapshot.Sequence,
+		Snapshot: true,
+		Bids:     MapLevelsToProto(snapshot.Bids),
+		Asks:     MapLevelsToProto(snapshot.Asks),
+	}
+}
+
+func MapDiffToProto(diff orderbook.Diff) *order.OrderBookUpdate {
+	updates := make([]*order.LevelUpdate, 0, len(diff.Updates))
+	for _, update := range diff.Updates {
+		updates = append(updates, &order.LevelUpdate{
+			Side:  update.Side,
+			Level: MapLevelToProto(update.Level),
+		})
+	}
+	return &order.OrderBookUpdate{
+		MarketId: diff.MarketId.String(),
+		Sequence: diff.Sequence,
+		Updates:  updates,
+	}
+}
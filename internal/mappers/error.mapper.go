@@ -0,0 +1,26 @@
+package mappers
+
+import (
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// MapErrorToProto converts an item error of a batch into its wire form, nil stays nil.
+func MapErrorToProto(err error) *order.ItemError {
+	if err == nil {
+		return nil
+	}
+
+	st := status.Convert(err)
+	itemError := &order.ItemError{
+		Code:    int32(st.Code()),
+		Message: st.Message(),
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			itemError.Reason = info.GetReason()
+		}
+	}
+	return itemError
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/ewik2k21/grpcOrderService/internal/models"
 	spot "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
 	"github.com/google/uuid"
+	"time"
 )
 
 func MapProtoToMarkets(resp *spot.ViewMarketsResponse) ([]*models.Market, error) {
@@ -14,11 +15,16 @@ func MapProtoToMarkets(resp *spot.ViewMarketsResponse) ([]*models.Market, error)
 		if err != nil {
 			return nil, err
 		}
+		var deletedAt *time.Time
+		if market.GetDeletedAt() != nil {
+			t := market.GetDeletedAt().AsTime()
+			deletedAt = &t
+		}
 		res = append(res, &models.Market{
 			ID:        marketId,
-			Name:      market.Name,
-			Enabled:   true,
-			DeletedAt: nil,
+			Name:      market.GetName(),
+			Enabled:   market.GetEnabled(),
+			DeletedAt: deletedAt,
 		})
 	}
 	return res, nil
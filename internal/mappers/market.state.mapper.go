@@ -0,0 +1,16 @@
+package mappers
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func MapMarketStateToProto(state models.MarketState) *order.MarketState {
+	return &order.MarketState{
+		MarketId:  state.MarketId.String(),
+		Mode:      state.Mode,
+		Reason:    state.Reason,
+		UpdatedAt: timestamppb.New(state.UpdatedAt),
+	}
+}
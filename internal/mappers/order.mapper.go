@@ -4,8 +4,18 @@ import (
 	"github.com/ewik2k21/grpcOrderService/internal/models"
 	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
 )
 
+// MapOptionalTime converts an optional time, nil stays nil.
+func MapOptionalTime(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
 func MapProtoToOrder(request *order.CreateOrderRequest) (*models.Order, error) {
 	userId, err := uuid.Parse(request.GetUserId())
 	if err != nil {
@@ -17,12 +27,61 @@ func MapProtoToOrder(request *order.CreateOrderRequest) (*models.Order, error) {
 		return nil, err
 	}
 
+	var expireAt *time.Time
+	if request.GetExpireAt() != nil {
+		t := request.GetExpireAt().AsTime()
+		expireAt = &t
+	}
+
 	return &models.Order{
-		UserId:    userId,
-		MarketId:  marketId,
-		OrderType: request.GetOrderType(),
-		Price:     request.GetPrice(),
-		Quantity:  request.GetPrice(),
+		ClientOrderId: request.GetClientOrderId(),
+		UserId:        userId,
+		UserRole:      request.GetUserRole(),
+		MarketId:      marketId,
+		OrderType:     request.GetOrderType(),
+		Price:         request.GetPrice(),
+		Quantity:      request.GetQuantity(),
+		TimeInForce:   request.GetTimeInForce(),
+		ExpireAt:      expireAt,
+		Side:          request.GetSide(),
+		StopPrice:     request.GetStopPrice(),
 	}, nil
 
 }
+
+func MapOrderToProto(o *models.Order) *order.Order {
+	res := &order.Order{
+		OrderId:        o.ID.String(),
+		UserId:         o.UserId.String(),
+		MarketId:       o.MarketId.String(),
+		OrderType:      o.OrderType,
+		Price:          o.Price,
+		Quantity:       o.Quantity,
+		Status:         o.Status,
+		ClientOrderId:  o.ClientOrderId,
+		TimeInForce:    o.TimeInForce,
+		Side:           o.Side,
+		StopPrice:      o.StopPrice,
+		FilledQuantity: o.FilledQuantity,
+		AvgFillPrice:   o.AvgFillPrice,
+		FeeTotal:       o.FeeTotal,
+		FeeAsset:       o.FeeAsset,
+		Version:        o.Version,
+		CreatedAt:      timestamppb.New(o.CreatedAt),
+		UpdatedAt:      timestamppb.New(o.UpdatedAt),
+		StatusReason:   o.StatusReason,
+		StatusDetail:   o.StatusDetail,
+		History:        make([]*order.OrderEvent, 0, len(o.History)),
+	}
+	res.ExpireAt = MapOptionalTime(o.ExpireAt)
+	res.TriggeredAt = MapOptionalTime(o.TriggeredAt)
+	res.TerminalAt = MapOptionalTime(o.TerminalAt)
+	for _, event := range o.History {
+		res.History = append(res.History, &order.OrderEvent{
+			At:     timestamppb.New(event.At),
+			Type:   event.Type,
+			Detail: event.Detail,
+		})
+	}
+	return res
+}
@@ -0,0 +1,29 @@
+package mappers
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func MapPositionToProto(p models.Position) *order.Position {
+	res := &order.Position{
+		UserId:        p.UserId.String(),
+		MarketId:      p.MarketId.String(),
+		NetQuantity:   p.NetQuantity,
+		AvgEntryPrice: p.AvgEntryPrice,
+		RealizedPnl:   p.RealizedPnl,
+	}
+	if !p.UpdatedAt.IsZero() {
+		res.UpdatedAt = timestamppb.New(p.UpdatedAt)
+	}
+	return res
+}
+
+func MapPositionsToProto(positions []models.Position) []*order.Position {
+	res := make([]*order.Position, 0, len(positions))
+	for _, position := range positions {
+		res = append(res, MapPositionToProto(position))
+	}
+	return res
+}
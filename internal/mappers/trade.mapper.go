@@ -0,0 +1,32 @@
+package mappers
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func MapTradeToProto(t *models.Trade) *order.Trade {
+	return &order.Trade{
+		TradeId:      t.ID.String(),
+		MarketId:     t.MarketId.String(),
+		BuyOrderId:   t.BuyOrderId.String(),
+		SellOrderId:  t.SellOrderId.String(),
+		Price:        t.Price,
+		Quantity:     t.Quantity,
+		ExecutedAt:   timestamppb.New(t.ExecutedAt),
+		TakerSide:    t.TakerSide,
+		BuyFee:       t.BuyFee,
+		BuyFeeAsset:  t.BuyFeeAsset,
+		SellFee:      t.SellFee,
+		SellFeeAsset: t.SellFeeAsset,
+	}
+}
+
+func MapTradesToProto(trades []*models.Trade) []*order.Trade {
+	res := make([]*order.Trade, 0, len(trades))
+	for _, trade := range trades {
+		res = append(res, MapTradeToProto(trade))
+	}
+	return res
+}
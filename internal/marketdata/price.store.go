@@ -0,0 +1,81 @@
+package marketdata
+
+import (
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+type PriceSource int
+
+const (
+	SourceReference PriceSource = iota
+	SourceLastTrade
+)
+
+func (s PriceSource) String() string {
+	if s == SourceLastTrade {
+		return "last_trade"
+	}
+	return "reference"
+}
+
+type Price struct {
+	Value     float64
+	Source    PriceSource
+	UpdatedAt time.Time
+}
+
+// PriceStore keeps the latest reference and last traded price per market.
+type PriceStore struct {
+	mu        sync.RWMutex
+	reference map[uuid.UUID]Price
+	lastTrade map[uuid.UUID]Price
+}
+
+func NewPriceStore() *PriceStore {
+	return &PriceStore{
+		reference: make(map[uuid.UUID]Price),
+		lastTrade: make(map[uuid.UUID]Price),
+	}
+}
+
+func (p *PriceStore) Set(marketId uuid.UUID, price Price) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if price.Source == SourceLastTrade {
+		p.lastTrade[marketId] = price
+		return
+	}
+	p.reference[marketId] = price
+}
+
+// Reference returns the reference price of marketId, falling back to the last traded price.
+func (p *PriceStore) Reference(marketId uuid.UUID) (Price, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if price, ok := p.reference[marketId]; ok {
+		return price, true
+	}
+	price, ok := p.lastTrade[marketId]
+	return price, ok
+}
+
+// Latest returns whichever of the reference and last traded price of marketId changed last.
+func (p *PriceStore) Latest(marketId uuid.UUID) (Price, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	reference, hasReference := p.reference[marketId]
+	lastTrade, hasLastTrade := p.lastTrade[marketId]
+	switch {
+	case hasReference && hasLastTrade:
+		if lastTrade.UpdatedAt.After(reference.UpdatedAt) {
+			return lastTrade, true
+		}
+		return reference, true
+	case hasLastTrade:
+		return lastTrade, true
+	default:
+		return reference, hasReference
+	}
+}
@@ -0,0 +1,239 @@
+package marketstate
+
+import (
+	"context"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/ewik2k21/grpcOrderService/internal/repositories"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+var (
+	MarketModes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "market_mode",
+			Help: "markets per trading mode other than TRADING",
+		},
+		[]string{"mode"},
+	)
+	DroppedStateSubscribers = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "market_state_dropped_subscribers_total",
+			Help: "market state stream subscribers dropped for not keeping up",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(MarketModes, DroppedStateSubscribers)
+}
+
+type subscriber struct {
+	marketId uuid.UUID
+	ch       chan models.MarketState
+}
+
+// Store holds the trading mode of every market in memory, persists changes through
+// the repository and streams them to subscribers. Markets without a state are TRADING.
+// Changes made by other instances are picked up by re-reading the repository.
+type Store struct {
+	repo       *repositories.MarketStateRepository
+	logger     *slog.Logger
+	bufferSize int
+
+	mu     sync.RWMutex
+	states map[uuid.UUID]models.MarketState
+	// sets counts the local changes, a sync that raced with one is skipped
+	sets        uint64
+	nextSubId   uint64
+	subscribers map[uint64]*subscriber
+}
+
+func NewStore(repo *repositories.MarketStateRepository, logger *slog.Logger, bufferSize int) *Store {
+	return &Store{
+		repo:        repo,
+		logger:      logger,
+		bufferSize:  bufferSize,
+		states:      make(map[uuid.UUID]models.MarketState),
+		subscribers: make(map[uint64]*subscriber),
+	}
+}
+
+// Load restores the persisted states, it is meant to run once at startup.
+func (s *Store) Load(ctx context.Context) error {
+	if _, err := s.sync(ctx); err != nil {
+		return err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.logger.Info("market states loaded", slog.Int("markets", len(s.states)))
+	return nil
+}
+
+// Run re-reads the persisted states every interval until ctx is done, so a halt set
+// on another instance takes effect here too.
+func (s *Store) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := s.sync(ctx)
+			if err != nil {
+				continue
+			}
+			if changed > 0 {
+				s.logger.Info("market states synced", slog.Int("changed", changed))
+			}
+		}
+	}
+}
+
+// sync replaces the states with the persisted ones and broadcasts every difference.
+// It leaves the states alone when a local change happened while reading.
+func (s *Store) sync(ctx context.Context) (int, error) {
+	s.mu.RLock()
+	sets := s.sets
+	s.mu.RUnlock()
+
+	states, err := s.repo.LoadAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sets != sets {
+		return 0, nil
+	}
+
+	stored := make(map[uuid.UUID]models.MarketState, len(states))
+	for _, state := range states {
+		stored[state.MarketId] = state
+	}
+	changed := 0
+	for marketId, state := range stored {
+		if current, ok := s.states[marketId]; !ok || !sameState(current, state) {
+			s.states[marketId] = state
+			s.publish(state)
+			changed++
+		}
+	}
+	for marketId, current := range s.states {
+		if _, ok := stored[marketId]; !ok {
+			delete(s.states, marketId)
+			s.publish(models.MarketState{MarketId: marketId, Mode: order.MarketMode_TRADING, UpdatedAt: current.UpdatedAt})
+			changed++
+		}
+	}
+	s.updateGauge()
+	return changed, nil
+}
+
+// Get returns the state of marketId.
+func (s *Store) Get(marketId uuid.UUID) models.MarketState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if state, ok := s.states[marketId]; ok {
+		return state
+	}
+	return models.MarketState{MarketId: marketId, Mode: order.MarketMode_TRADING}
+}
+
+// Set persists the new mode of marketId before it takes effect and broadcasts it.
+func (s *Store) Set(ctx context.Context, marketId uuid.UUID, mode order.MarketMode, reason string) (models.MarketState, error) {
+	state := models.MarketState{
+		MarketId:  marketId,
+		Mode:      mode,
+		Reason:    reason,
+		UpdatedAt: time.Now(),
+	}
+
+	if err := s.repo.Save(ctx, state); err != nil {
+		return models.MarketState{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sets++
+	if state.AcceptsOrders() {
+		delete(s.states, marketId)
+	} else {
+		s.states[marketId] = state
+	}
+	s.updateGauge()
+	s.publish(state)
+
+	s.logger.Warn("market mode changed",
+		slog.String("market_id", marketId.String()),
+		slog.String("mode", mode.String()),
+		slog.String("reason", reason))
+	return state, nil
+}
+
+// sameState ignores the monotonic clock reading a state loses once persisted.
+func sameState(a, b models.MarketState) bool {
+	return a.Mode == b.Mode && a.Reason == b.Reason && a.UpdatedAt.Equal(b.UpdatedAt)
+}
+
+// Subscribe returns the states of marketId, or of every market when it is uuid.Nil,
+// that are not TRADING and a channel of every change after them.
+func (s *Store) Subscribe(marketId uuid.UUID) ([]models.MarketState, <-chan models.MarketState, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.nextSubId
+	s.nextSubId++
+	sub := &subscriber{marketId: marketId, ch: make(chan models.MarketState, s.bufferSize)}
+	s.subscribers[id] = sub
+
+	current := make([]models.MarketState, 0)
+	for _, state := range s.states {
+		if marketId == uuid.Nil || state.MarketId == marketId {
+			current = append(current, state)
+		}
+	}
+
+	return current, sub.ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if _, ok := s.subscribers[id]; ok {
+			delete(s.subscribers, id)
+			close(sub.ch)
+		}
+	}
+}
+
+// publish must be called with s.mu held.
+func (s *Store) publish(state models.MarketState) {
+	for id, sub := range s.subscribers {
+		if sub.marketId != uuid.Nil && sub.marketId != state.MarketId {
+			continue
+		}
+		select {
+		case sub.ch <- state:
+		default:
+			delete(s.subscribers, id)
+			close(sub.ch)
+			DroppedStateSubscribers.Inc()
+			s.logger.Warn("dropped slow market state subscriber")
+		}
+	}
+}
+
+// updateGauge must be called with s.mu held.
+func (s *Store) updateGauge() {
+	counts := map[order.MarketMode]int{order.MarketMode_HALTED: 0, order.MarketMode_CANCEL_ONLY: 0}
+	for _, state := range s.states {
+		counts[state.Mode]++
+	}
+	for mode, count := range counts {
+		MarketModes.WithLabelValues(mode.String()).Set(float64(count))
+	}
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"github.com/google/uuid"
+	"strings"
 	"time"
 )
 
@@ -11,3 +12,31 @@ type Market struct {
 	Enabled   bool
 	DeletedAt *time.Time
 }
+
+func (m *Market) Equal(other *Market) bool {
+	if m.ID != other.ID || m.Name != other.Name || m.Enabled != other.Enabled {
+		return false
+	}
+	if m.DeletedAt == nil || other.DeletedAt == nil {
+		return m.DeletedAt == other.DeletedAt
+	}
+	return m.DeletedAt.Equal(*other.DeletedAt)
+}
+
+// Assets splits a market name like BTC/USDT, BTC-USDT or BTC_USDT into its base and quote asset.
+func (m *Market) Assets() (string, string, bool) {
+	return ParseAssets(m.Name)
+}
+
+func ParseAssets(marketName string) (string, string, bool) {
+	base, quote, ok := strings.Cut(marketName, "/")
+	if !ok {
+		if i := strings.IndexAny(marketName, "-_"); i >= 0 {
+			base, quote, ok = marketName[:i], marketName[i+1:], true
+		}
+	}
+	if !ok || base == "" || quote == "" {
+		return "", "", false
+	}
+	return strings.ToUpper(base), strings.ToUpper(quote), true
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"github.com/google/uuid"
+	"time"
+)
+
+// MarketState is the trading mode operations set for a market.
+type MarketState struct {
+	MarketId  uuid.UUID        `json:"market_id"`
+	Mode      order.MarketMode `json:"mode"`
+	Reason    string           `json:"reason,omitempty"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// AcceptsOrders reports whether new orders may be placed.
+func (s MarketState) AcceptsOrders() bool {
+	return s.Mode == order.MarketMode_TRADING
+}
+
+// AcceptsCancels reports whether open orders may be cancelled.
+func (s MarketState) AcceptsCancels() bool {
+	return s.Mode != order.MarketMode_HALTED
+}
@@ -2,14 +2,84 @@ package models
 
 import (
 	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
 	"github.com/google/uuid"
+	"time"
 )
 
 type Order struct {
-	UserId    uuid.UUID
-	MarketId  uuid.UUID
-	OrderType order.OrderType
-	Price     float64
-	Quantity  float64
-	Status    order.Status
+	ID            uuid.UUID
+	ClientOrderId string
+	UserId        uuid.UUID
+	UserRole      pkg.UserRole
+	MarketId      uuid.UUID
+	OrderType     order.OrderType
+	Price         float64
+	Quantity      float64
+	Status        order.Status
+	TimeInForce   order.TimeInForce
+	ExpireAt      *time.Time
+	Side          order.Side
+	StopPrice     float64
+	TriggeredAt   *time.Time
+	// FilledQuantity and AvgFillPrice are kept in sync with the trades of the order
+	FilledQuantity float64
+	AvgFillPrice   float64
+	// FeeTotal is the sum of the fees of the fills, paid in FeeAsset
+	FeeTotal float64
+	FeeAsset string
+	// Version starts at 1 and grows with every change of the stored order
+	Version   int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// TerminalAt is set once the order reaches a terminal status
+	TerminalAt *time.Time
+	// StatusReason and StatusDetail explain the last status change
+	StatusReason order.StatusReason
+	StatusDetail string
+	Audit        *OrderAudit
+	History      []OrderEvent
+}
+
+// QuantityEpsilon absorbs float rounding when comparing quantities.
+const QuantityEpsilon = 1e-9
+
+// Remaining is the quantity still to fill.
+func (o *Order) Remaining() float64 {
+	return o.Quantity - o.FilledQuantity
+}
+
+// IsFilled reports whether nothing is left to fill.
+func (o *Order) IsFilled() bool {
+	return o.Remaining() <= QuantityEpsilon
+}
+
+// OrderEvent is one entry of the order history.
+type OrderEvent struct {
+	At     time.Time
+	Type   string
+	Detail string
+}
+
+// IsStop reports whether orderType waits for a stop price before it can execute.
+func IsStop(orderType order.OrderType) bool {
+	return orderType == order.OrderType_STOP_MARKET || orderType == order.OrderType_STOP_LIMIT
+}
+
+// IsTerminal reports whether no further status change is possible.
+func IsTerminal(status order.Status) bool {
+	switch status {
+	case order.Status_PROCESSED, order.Status_CANCELLED, order.Status_EXPIRED:
+		return true
+	default:
+		return false
+	}
+}
+
+// OrderAudit records the state of the market the order was validated against.
+type OrderAudit struct {
+	MarketName      string
+	MarketEnabled   bool
+	MarketDeletedAt *time.Time
+	ValidatedAt     time.Time
 }
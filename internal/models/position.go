@@ -0,0 +1,46 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"math"
+	"time"
+)
+
+// Position is the net exposure of a user in one market.
+type Position struct {
+	UserId   uuid.UUID
+	MarketId uuid.UUID
+	// NetQuantity is positive for a long and negative for a short position
+	NetQuantity   float64
+	AvgEntryPrice float64
+	// RealizedPnl is in the quote asset of the market
+	RealizedPnl float64
+	UpdatedAt   time.Time
+}
+
+// Apply adds a fill of signed quantity at price, buys are positive and sells negative.
+// Reducing fills realize PnL against the average entry price, a fill that flips the
+// position opens the rest at price.
+func (p *Position) Apply(quantity, price float64, at time.Time) {
+	p.UpdatedAt = at
+	net := p.NetQuantity
+
+	if net == 0 || math.Signbit(net) == math.Signbit(quantity) {
+		size := math.Abs(net) + math.Abs(quantity)
+		p.AvgEntryPrice = (math.Abs(net)*p.AvgEntryPrice + math.Abs(quantity)*price) / size
+		p.NetQuantity = net + quantity
+		return
+	}
+
+	closing := min(math.Abs(quantity), math.Abs(net))
+	p.RealizedPnl += closing * (price - p.AvgEntryPrice) * math.Copysign(1, net)
+	p.NetQuantity = net + quantity
+
+	switch {
+	case math.Abs(p.NetQuantity) <= QuantityEpsilon:
+		p.NetQuantity = 0
+		p.AvgEntryPrice = 0
+	case math.Signbit(p.NetQuantity) != math.Signbit(net):
+		p.AvgEntryPrice = price
+	}
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPositionApply(t *testing.T) {
+	type fill struct {
+		quantity, price float64
+	}
+	tests := []struct {
+		name        string
+		fills       []fill
+		wantNet     float64
+		wantAvg     float64
+		wantRealize float64
+	}{
+		{name: "open long", fills: []fill{{2, 100}}, wantNet: 2, wantAvg: 100},
+		{name: "add to long averages the entry", fills: []fill{{1, 100}, {3, 120}}, wantNet: 4, wantAvg: 115},
+		{name: "reduce long realizes profit", fills: []fill{{2, 100}, {-1, 130}}, wantNet: 1, wantAvg: 100, wantRealize: 30},
+		{name: "close long at a loss", fills: []fill{{2, 100}, {-2, 90}}, wantNet: 0, wantAvg: 0, wantRealize: -20},
+		{name: "flip long to short opens at the fill price", fills: []fill{{1, 100}, {-3, 110}}, wantNet: -2, wantAvg: 110, wantRealize: 10},
+		{name: "open short", fills: []fill{{-2, 50}}, wantNet: -2, wantAvg: 50},
+		{name: "cover short realizes profit", fills: []fill{{-2, 50}, {1, 40}}, wantNet: -1, wantAvg: 50, wantRealize: 10},
+		{name: "flip short to long", fills: []fill{{-1, 50}, {2, 60}}, wantNet: 1, wantAvg: 60, wantRealize: -10},
+		{name: "rounding dust closes the position", fills: []fill{{0.3, 10}, {-0.1, 10}, {-0.2, 10}}, wantNet: 0, wantAvg: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Position
+			at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			for i, f := range tt.fills {
+				p.Apply(f.quantity, f.price, at.Add(time.Duration(i)*time.Second))
+			}
+			if math.Abs(p.NetQuantity-tt.wantNet) > QuantityEpsilon {
+				t.Errorf("net quantity %v, want %v", p.NetQuantity, tt.wantNet)
+			}
+			if math.Abs(p.AvgEntryPrice-tt.wantAvg) > QuantityEpsilon {
+				t.Errorf("average entry price %v, want %v", p.AvgEntryPrice, tt.wantAvg)
+			}
+			if math.Abs(p.RealizedPnl-tt.wantRealize) > QuantityEpsilon {
+				t.Errorf("realized pnl %v, want %v", p.RealizedPnl, tt.wantRealize)
+			}
+			if want := at.Add(time.Duration(len(tt.fills)-1) * time.Second); !p.UpdatedAt.Equal(want) {
+				t.Errorf("updated at %v, want %v", p.UpdatedAt, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Trade is one execution between a resting maker order and an incoming taker order.
+type Trade struct {
+	ID          uuid.UUID
+	MarketId    uuid.UUID
+	BuyOrderId  uuid.UUID
+	SellOrderId uuid.UUID
+	BuyUserId   uuid.UUID
+	SellUserId  uuid.UUID
+	Price       float64
+	Quantity    float64
+	ExecutedAt  time.Time
+	TakerSide   order.Side
+	// the buyer pays its fee in the base asset, the seller in the quote asset
+	BuyFee       float64
+	BuyFeeAsset  string
+	SellFee      float64
+	SellFeeAsset string
+}
+
+func (t *Trade) TakerOrderId() uuid.UUID {
+	if t.TakerSide == order.Side_BUY {
+		return t.BuyOrderId
+	}
+	return t.SellOrderId
+}
+
+func (t *Trade) MakerOrderId() uuid.UUID {
+	if t.TakerSide == order.Side_BUY {
+		return t.SellOrderId
+	}
+	return t.BuyOrderId
+}
@@ -0,0 +1,165 @@
+package orderbook
+
+import (
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"log/slog"
+	"sync"
+)
+
+var (
+	DroppedBookSubscribers = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "order_book_dropped_subscribers_total",
+			Help: "order book stream subscribers dropped for not keeping up",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(DroppedBookSubscribers)
+}
+
+// Snapshot is the state of a book at Sequence.
+type Snapshot struct {
+	MarketId uuid.UUID
+	Sequence uint64
+	Bids     []Level
+	Asks     []Level
+}
+
+// Diff holds the level changes that move a book from Sequence-1 to Sequence.
+type Diff struct {
+	MarketId uuid.UUID
+	Sequence uint64
+	Updates  []LevelUpdate
+}
+
+type market struct {
+	mu          sync.Mutex
+	book        *Book
+	sequence    uint64
+	nextSubId   uint64
+	subscribers map[uint64]chan Diff
+}
+
+// Manager owns the books of every market and streams their changes with sequence numbers.
+type Manager struct {
+	logger     *slog.Logger
+	bufferSize int
+
+	mu      sync.Mutex
+	markets map[uuid.UUID]*market
+}
+
+func NewManager(logger *slog.Logger, bufferSize int) *Manager {
+	return &Manager{
+		logger:     logger,
+		bufferSize: bufferSize,
+		markets:    make(map[uuid.UUID]*market),
+	}
+}
+
+// market returns the book of marketId and creates it on first use.
+func (m *Manager) market(marketId uuid.UUID) *market {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mkt, ok := m.markets[marketId]
+	if !ok {
+		mkt = &market{book: NewBook(), subscribers: make(map[uint64]chan Diff)}
+		m.markets[marketId] = mkt
+	}
+	return mkt
+}
+
+// Apply runs fn with exclusive access to the book of marketId and publishes the
+// level updates it returns as one diff.
+func (m *Manager) Apply(marketId uuid.UUID, fn func(book *Book) []LevelUpdate) {
+	mkt := m.market(marketId)
+	mkt.mu.Lock()
+	defer mkt.mu.Unlock()
+
+	updates := fn(mkt.book)
+	if len(updates) == 0 {
+		return
+	}
+
+	mkt.sequence++
+	diff := Diff{MarketId: marketId, Sequence: mkt.sequence, Updates: updates}
+	for id, ch := range mkt.subscribers {
+		select {
+		case ch <- diff:
+		default:
+			delete(mkt.subscribers, id)
+			close(ch)
+			DroppedBookSubscribers.Inc()
+			m.logger.Warn("dropped slow order book subscriber", slog.String("market_id", marketId.String()))
+		}
+	}
+}
+
+func (m *Manager) Add(marketId uuid.UUID, resting *Resting) {
+	m.Apply(marketId, func(book *Book) []LevelUpdate {
+		return []LevelUpdate{book.Add(resting)}
+	})
+}
+
+// Remove takes an order out of its book, false when it was not resting.
+func (m *Manager) Remove(marketId, orderId uuid.UUID) bool {
+	removed := false
+	m.Apply(marketId, func(book *Book) []LevelUpdate {
+		update, ok := book.Remove(orderId)
+		if !ok {
+			return nil
+		}
+		removed = true
+		return []LevelUpdate{update}
+	})
+	return removed
+}
+
+// Snapshot returns up to depth levels per side, every level when depth <= 0. A market
+// without a book gets an empty snapshot and no book is created for it.
+func (m *Manager) Snapshot(marketId uuid.UUID, depth int) Snapshot {
+	m.mu.Lock()
+	mkt, ok := m.markets[marketId]
+	m.mu.Unlock()
+	if !ok {
+		return Snapshot{MarketId: marketId, Bids: []Level{}, Asks: []Level{}}
+	}
+	mkt.mu.Lock()
+	defer mkt.mu.Unlock()
+	return mkt.snapshot(marketId, depth)
+}
+
+// Subscribe returns a full snapshot and a channel of every diff after it. The
+// channel is closed when the subscriber falls behind.
+func (m *Manager) Subscribe(marketId uuid.UUID) (Snapshot, <-chan Diff, func()) {
+	mkt := m.market(marketId)
+	mkt.mu.Lock()
+	defer mkt.mu.Unlock()
+
+	id := mkt.nextSubId
+	mkt.nextSubId++
+	ch := make(chan Diff, m.bufferSize)
+	mkt.subscribers[id] = ch
+
+	return mkt.snapshot(marketId, 0), ch, func() {
+		mkt.mu.Lock()
+		defer mkt.mu.Unlock()
+		if _, ok := mkt.subscribers[id]; ok {
+			delete(mkt.subscribers, id)
+			close(ch)
+		}
+	}
+}
+
+func (mkt *market) snapshot(marketId uuid.UUID, depth int) Snapshot {
+	bids, asks := mkt.book.Depth(depth)
+	return Snapshot{
+		MarketId: marketId,
+		Sequence: mkt.sequence,
+		Bids:     bids,
+		Asks:     asks,
+	}
+}
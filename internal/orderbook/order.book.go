@@ -0,0 +1,213 @@
+package orderbook
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"github.com/google/uuid"
+	"slices"
+)
+
+// Level is the aggregated state of one price level.
+type Level struct {
+	Price      float64
+	Quantity   float64
+	OrderCount int
+}
+
+// LevelUpdate is the new state of a level after a change, Quantity 0 means the level is gone.
+type LevelUpdate struct {
+	Side order.Side
+	Level
+}
+
+// Resting is an order waiting in the book.
+type Resting struct {
+	OrderId   uuid.UUID
+	UserId    uuid.UUID
+	Side      order.Side
+	Price     float64
+	Remaining float64
+}
+
+type level struct {
+	price    float64
+	quantity float64
+	orders   []*Resting
+}
+
+type side struct {
+	side   order.Side
+	prices []float64
+	levels map[float64]*level
+}
+
+func newSide(s order.Side) *side {
+	return &side{side: s, levels: make(map[float64]*level)}
+}
+
+// better reports whether price a has priority over price b on this side.
+func (s *side) better(a, b float64) bool {
+	if s.side == order.Side_BUY {
+		return a > b
+	}
+	return a < b
+}
+
+func (s *side) search(price float64) (int, bool) {
+	return slices.BinarySearchFunc(s.prices, price, func(a, b float64) int {
+		switch {
+		case a == b:
+			return 0
+		case s.better(a, b):
+			return -1
+		default:
+			return 1
+		}
+	})
+}
+
+func (s *side) add(resting *Resting) LevelUpdate {
+	lvl, ok := s.levels[resting.Price]
+	if !ok {
+		lvl = &level{price: resting.Price}
+		s.levels[resting.Price] = lvl
+		i, _ := s.search(resting.Price)
+		s.prices = slices.Insert(s.prices, i, resting.Price)
+	}
+	lvl.orders = append(lvl.orders, resting)
+	lvl.quantity += resting.Remaining
+	return s.update(lvl)
+}
+
+func (s *side) remove(resting *Resting) LevelUpdate {
+	lvl := s.levels[resting.Price]
+	if i := slices.Index(lvl.orders, resting); i >= 0 {
+		lvl.orders = slices.Delete(lvl.orders, i, i+1)
+		lvl.quantity -= resting.Remaining
+	}
+	if len(lvl.orders) == 0 {
+		delete(s.levels, resting.Price)
+		if i, ok := s.search(resting.Price); ok {
+			s.prices = slices.Delete(s.prices, i, i+1)
+		}
+		return LevelUpdate{Side: s.side, Level: Level{Price: resting.Price}}
+	}
+	return s.update(lvl)
+}
+
+// reduce takes the fill off the level before a filled order leaves it, remove only
+// takes off what is still remaining.
+func (s *side) reduce(resting *Resting, quantity float64) LevelUpdate {
+	lvl := s.levels[resting.Price]
+	lvl.quantity -= quantity
+	resting.Remaining -= quantity
+	if resting.Remaining <= models.QuantityEpsilon {
+		return s.remove(resting)
+	}
+	return s.update(lvl)
+}
+
+func (s *side) update(lvl *level) LevelUpdate {
+	return LevelUpdate{
+		Side: s.side,
+		Level: Level{
+			Price:      lvl.price,
+			Quantity:   lvl.quantity,
+			OrderCount: len(lvl.orders),
+		},
+	}
+}
+
+func (s *side) depth(n int) []Level {
+	if n <= 0 || n > len(s.prices) {
+		n = len(s.prices)
+	}
+	res := make([]Level, 0, n)
+	for _, price := range s.prices[:n] {
+		res = append(res, s.update(s.levels[price]).Level)
+	}
+	return res
+}
+
+// Book is the limit order book of one market, orders on a level keep time priority.
+type Book struct {
+	bids    *side
+	asks    *side
+	resting map[uuid.UUID]*Resting
+}
+
+func NewBook() *Book {
+	return &Book{
+		bids:    newSide(order.Side_BUY),
+		asks:    newSide(order.Side_SELL),
+		resting: make(map[uuid.UUID]*Resting),
+	}
+}
+
+func (b *Book) sideOf(s order.Side) *side {
+	if s == order.Side_BUY {
+		return b.bids
+	}
+	return b.asks
+}
+
+// Add appends the order to the back of its price level.
+func (b *Book) Add(resting *Resting) LevelUpdate {
+	b.resting[resting.OrderId] = resting
+	return b.sideOf(resting.Side).add(resting)
+}
+
+// Remove takes the order out of the book, false when it was not resting.
+func (b *Book) Remove(orderId uuid.UUID) (LevelUpdate, bool) {
+	resting, ok := b.resting[orderId]
+	if !ok {
+		return LevelUpdate{}, false
+	}
+	delete(b.resting, orderId)
+	return b.sideOf(resting.Side).remove(resting), true
+}
+
+func (b *Book) Get(orderId uuid.UUID) (*Resting, bool) {
+	resting, ok := b.resting[orderId]
+	return resting, ok
+}
+
+// Depth returns up to n levels per side from the best price, every level when n <= 0.
+func (b *Book) Depth(n int) (bids, asks []Level) {
+	return b.bids.depth(n), b.asks.depth(n)
+}
+
+// Best returns the order with priority on side s, the front of its best price level.
+func (b *Book) Best(s order.Side) (*Resting, bool) {
+	bookSide := b.sideOf(s)
+	if len(bookSide.prices) == 0 {
+		return nil, false
+	}
+	return bookSide.levels[bookSide.prices[0]].orders[0], true
+}
+
+// Reduce takes quantity off a resting order, the order leaves the book once nothing remains.
+func (b *Book) Reduce(orderId uuid.UUID, quantity float64) (LevelUpdate, bool) {
+	resting, ok := b.resting[orderId]
+	if !ok {
+		return LevelUpdate{}, false
+	}
+	update := b.sideOf(resting.Side).reduce(resting, quantity)
+	if resting.Remaining <= models.QuantityEpsilon {
+		delete(b.resting, orderId)
+	}
+	return update, true
+}
+
+// Liquidity sums the quantity on side s from the best price while accept allows the price.
+func (b *Book) Liquidity(s order.Side, accept func(price float64) bool) float64 {
+	bookSide := b.sideOf(s)
+	total := 0.0
+	for _, price := range bookSide.prices {
+		if !accept(price) {
+			break
+		}
+		total += bookSide.levels[price].quantity
+	}
+	return total
+}
@@ -0,0 +1,104 @@
+package orderbook
+
+import (
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"github.com/google/uuid"
+	"math"
+	"testing"
+)
+
+func restingAsk(price, quantity float64) *Resting {
+	return &Resting{OrderId: uuid.New(), UserId: uuid.New(), Side: order.Side_SELL, Price: price, Remaining: quantity}
+}
+
+func acceptAll(float64) bool { return true }
+
+func TestBookReduce(t *testing.T) {
+	tests := []struct {
+		name       string
+		level      []float64
+		reduce     int
+		quantity   float64
+		wantUpdate Level
+		wantLeft   bool
+	}{
+		{"full fill of the only order", []float64{5}, 0, 5, Level{Price: 10}, false},
+		{"partial fill of the only order", []float64{5}, 0, 2, Level{Price: 10, Quantity: 3, OrderCount: 1}, true},
+		{"full fill of the front order", []float64{5, 3}, 0, 5, Level{Price: 10, Quantity: 3, OrderCount: 1}, false},
+		{"partial fill of the front order", []float64{5, 3}, 0, 4, Level{Price: 10, Quantity: 4, OrderCount: 2}, true},
+		{"full fill of the back order", []float64{5, 3}, 1, 3, Level{Price: 10, Quantity: 5, OrderCount: 1}, false},
+		{"fill within epsilon", []float64{5, 3}, 0, 5 - 1e-12, Level{Price: 10, Quantity: 3, OrderCount: 1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book := NewBook()
+			orders := make([]*Resting, 0, len(tt.level))
+			for _, quantity := range tt.level {
+				resting := restingAsk(10, quantity)
+				book.Add(resting)
+				orders = append(orders, resting)
+			}
+
+			update, ok := book.Reduce(orders[tt.reduce].OrderId, tt.quantity)
+			if !ok {
+				t.Fatal("order not resting")
+			}
+			if update.Side != order.Side_SELL || update.Price != tt.wantUpdate.Price ||
+				update.OrderCount != tt.wantUpdate.OrderCount || math.Abs(update.Quantity-tt.wantUpdate.Quantity) > 1e-9 {
+				t.Fatalf("update %+v, want %+v", update, tt.wantUpdate)
+			}
+			if _, left := book.Get(orders[tt.reduce].OrderId); left != tt.wantLeft {
+				t.Fatalf("order still resting %v, want %v", left, tt.wantLeft)
+			}
+			if liquidity := book.Liquidity(order.Side_SELL, acceptAll); math.Abs(liquidity-tt.wantUpdate.Quantity) > 1e-9 {
+				t.Fatalf("liquidity %v, want %v", liquidity, tt.wantUpdate.Quantity)
+			}
+			_, asks := book.Depth(0)
+			if tt.wantUpdate.OrderCount == 0 && len(asks) != 0 {
+				t.Fatalf("empty level still in depth %+v", asks)
+			}
+		})
+	}
+}
+
+func TestBookReduceAcrossLevels(t *testing.T) {
+	book := NewBook()
+	first, second, third := restingAsk(10, 5), restingAsk(10, 3), restingAsk(11, 2)
+	for _, resting := range []*Resting{first, second, third} {
+		book.Add(resting)
+	}
+
+	//walk the book the way matching does, always taking the best order
+	for _, fill := range []float64{5, 1, 2, 2} {
+		best, ok := book.Best(order.Side_SELL)
+		if !ok {
+			t.Fatal("book empty too early")
+		}
+		book.Reduce(best.OrderId, fill)
+	}
+
+	if liquidity := book.Liquidity(order.Side_SELL, acceptAll); liquidity != 0 {
+		t.Fatalf("liquidity %v after filling everything", liquidity)
+	}
+	if _, asks := book.Depth(0); len(asks) != 0 {
+		t.Fatalf("depth %+v after filling everything", asks)
+	}
+	if _, ok := book.Best(order.Side_SELL); ok {
+		t.Fatal("best order left after filling everything")
+	}
+}
+
+func TestBookLiquidityStopsAtPrice(t *testing.T) {
+	book := NewBook()
+	book.Add(restingAsk(10, 5))
+	book.Add(restingAsk(10, 3))
+	book.Add(restingAsk(12, 4))
+
+	upTo11 := func(price float64) bool { return price <= 11 }
+	if liquidity := book.Liquidity(order.Side_SELL, upTo11); liquidity != 8 {
+		t.Fatalf("liquidity %v, want 8", liquidity)
+	}
+	if liquidity := book.Liquidity(order.Side_SELL, acceptAll); liquidity != 12 {
+		t.Fatalf("liquidity %v, want 12", liquidity)
+	}
+}
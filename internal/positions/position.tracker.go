@@ -0,0 +1,148 @@
+package positions
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	DroppedPositionSubscribers = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "position_updates_dropped_subscribers_total",
+			Help: "position stream subscribers dropped for not keeping up",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(DroppedPositionSubscribers)
+}
+
+type positionKey struct {
+	userId   uuid.UUID
+	marketId uuid.UUID
+}
+
+type subscriber struct {
+	userId uuid.UUID
+	ch     chan models.Position
+}
+
+// Tracker keeps the position of every user and market up to date from fills and
+// streams every change. Like the orders and trades they come from, positions live
+// in the memory of this process and start empty on every restart. A subscriber that does not keep up is dropped and its
+// channel closed so it can resubscribe.
+type Tracker struct {
+	logger     *slog.Logger
+	bufferSize int
+
+	mu          sync.Mutex
+	positions   map[positionKey]*models.Position
+	nextSubId   uint64
+	subscribers map[uint64]*subscriber
+}
+
+func NewTracker(logger *slog.Logger, bufferSize int) *Tracker {
+	return &Tracker{
+		logger:      logger,
+		bufferSize:  bufferSize,
+		positions:   make(map[positionKey]*models.Position),
+		subscribers: make(map[uint64]*subscriber),
+	}
+}
+
+// Apply updates the positions of both sides of trade and publishes them.
+func (t *Tracker) Apply(trade *models.Trade) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, position := range t.apply(trade) {
+		t.publish(position)
+	}
+}
+
+// apply must be called with t.mu held.
+func (t *Tracker) apply(trade *models.Trade) []*models.Position {
+	buyer := t.position(trade.BuyUserId, trade.MarketId)
+	buyer.Apply(trade.Quantity, trade.Price, trade.ExecutedAt)
+	if trade.BuyUserId == trade.SellUserId {
+		buyer.Apply(-trade.Quantity, trade.Price, trade.ExecutedAt)
+		return []*models.Position{buyer}
+	}
+	seller := t.position(trade.SellUserId, trade.MarketId)
+	seller.Apply(-trade.Quantity, trade.Price, trade.ExecutedAt)
+	return []*models.Position{buyer, seller}
+}
+
+// position must be called with t.mu held.
+func (t *Tracker) position(userId, marketId uuid.UUID) *models.Position {
+	key := positionKey{userId: userId, marketId: marketId}
+	position, ok := t.positions[key]
+	if !ok {
+		position = &models.Position{UserId: userId, MarketId: marketId}
+		t.positions[key] = position
+	}
+	return position
+}
+
+// publish must be called with t.mu held.
+func (t *Tracker) publish(position *models.Position) {
+	for id, sub := range t.subscribers {
+		if sub.userId != position.UserId {
+			continue
+		}
+		select {
+		case sub.ch <- *position:
+		default:
+			delete(t.subscribers, id)
+			close(sub.ch)
+			DroppedPositionSubscribers.Inc()
+			t.logger.Warn("dropped slow position subscriber", slog.String("user_id", sub.userId.String()))
+		}
+	}
+}
+
+// Positions returns the positions of userId sorted by market, only marketId when it is set.
+func (t *Tracker) Positions(userId, marketId uuid.UUID) []models.Position {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.snapshot(userId, marketId)
+}
+
+// snapshot must be called with t.mu held.
+func (t *Tracker) snapshot(userId, marketId uuid.UUID) []models.Position {
+	res := make([]models.Position, 0)
+	for key, position := range t.positions {
+		if key.userId == userId && (marketId == uuid.Nil || key.marketId == marketId) {
+			res = append(res, *position)
+		}
+	}
+	slices.SortFunc(res, func(a, b models.Position) int {
+		return strings.Compare(a.MarketId.String(), b.MarketId.String())
+	})
+	return res
+}
+
+// Subscribe returns the current positions of userId and a channel of every change after them.
+func (t *Tracker) Subscribe(userId uuid.UUID) ([]models.Position, <-chan models.Position, func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	id := t.nextSubId
+	t.nextSubId++
+	sub := &subscriber{userId: userId, ch: make(chan models.Position, t.bufferSize)}
+	t.subscribers[id] = sub
+
+	return t.snapshot(userId, uuid.Nil), sub.ch, func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		if _, ok := t.subscribers[id]; ok {
+			delete(t.subscribers, id)
+			close(sub.ch)
+		}
+	}
+}
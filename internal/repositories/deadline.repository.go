@@ -0,0 +1,201 @@
+package repositories
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+const (
+	cancelDeadlinesKey = "cancel_all_after:deadlines"
+	cancelLeaderKey    = "cancel_all_after:leader"
+	cancelInstancesKey = "cancel_all_after:instances"
+	cancelAcksPrefix   = "cancel_all_after:acks:"
+	// cancelAcksTTL bounds how long the acks of a deadline nobody claims are kept
+	cancelAcksTTL = time.Hour
+)
+
+// claimDeadline removes a deadline only while it still has the expected score and
+// every live instance acked it, a deadline the user pushed back in the meantime stays.
+var claimDeadline = redis.NewScript(`
+if redis.call("ZSCORE", KEYS[1], ARGV[1]) ~= ARGV[2] then
+	return 0
+end
+for i = 3, #ARGV do
+	if redis.call("SISMEMBER", KEYS[2], ARGV[i]) == 0 then
+		return 0
+	end
+end
+redis.call("DEL", KEYS[2])
+return redis.call("ZREM", KEYS[1], ARGV[1])`)
+
+// lead takes the leader key when it is free and extends it when it is already ours.
+var lead = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return 1
+end
+return 0`)
+
+// Deadline is the time the open orders of a user are cancelled at unless it is pushed back.
+type Deadline struct {
+	UserId uuid.UUID
+	At     time.Time
+	score  string
+}
+
+// DeadlineRepository stores the cancel all after deadlines in a redis sorted set
+// scored by unix milliseconds, so every instance sees them and a new leader picks
+// them up where the previous one stopped. Orders live in the memory of the instance
+// that took them, so every instance acks a due deadline once it cancelled its share
+// of the orders, and the deadline is only removed after all live instances acked.
+type DeadlineRepository struct {
+	redisClient *redis.Client
+	logger      *slog.Logger
+}
+
+func NewDeadlineRepository(redisClient *redis.Client, logger *slog.Logger) *DeadlineRepository {
+	return &DeadlineRepository{
+		redisClient: redisClient,
+		logger:      logger,
+	}
+}
+
+// Set replaces the deadline of userId.
+func (r *DeadlineRepository) Set(ctx context.Context, userId uuid.UUID, at time.Time) error {
+	err := r.redisClient.ZAdd(ctx, cancelDeadlinesKey, redis.Z{
+		Score:  float64(at.UnixMilli()),
+		Member: userId.String(),
+	}).Err()
+	if err != nil {
+		r.logger.Error("failed save cancel deadline", slog.String("error", err.Error()))
+		return err
+	}
+	return nil
+}
+
+// Clear removes the deadline of userId.
+func (r *DeadlineRepository) Clear(ctx context.Context, userId uuid.UUID) error {
+	if err := r.redisClient.ZRem(ctx, cancelDeadlinesKey, userId.String()).Err(); err != nil {
+		r.logger.Error("failed delete cancel deadline", slog.String("error", err.Error()))
+		return err
+	}
+	return nil
+}
+
+// Due returns the deadlines at or before now, members that are no user id are skipped.
+func (r *DeadlineRepository) Due(ctx context.Context, now time.Time) ([]Deadline, error) {
+	stored, err := r.redisClient.ZRangeByScoreWithScores(ctx, cancelDeadlinesKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now.UnixMilli(), 10),
+	}).Result()
+	if err != nil {
+		r.logger.Error("failed load cancel deadlines", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	deadlines := make([]Deadline, 0, len(stored))
+	for _, z := range stored {
+		member, _ := z.Member.(string)
+		userId, err := uuid.Parse(member)
+		if err != nil {
+			r.logger.Error("failed decode cancel deadline", slog.String("user_id", member))
+			continue
+		}
+		deadlines = append(deadlines, Deadline{
+			UserId: userId,
+			At:     time.UnixMilli(int64(z.Score)),
+			score:  strconv.FormatInt(int64(z.Score), 10),
+		})
+	}
+	return deadlines, nil
+}
+
+// Heartbeat marks instanceId as live at now.
+func (r *DeadlineRepository) Heartbeat(ctx context.Context, instanceId string, now time.Time) error {
+	err := r.redisClient.ZAdd(ctx, cancelInstancesKey, redis.Z{
+		Score:  float64(now.UnixMilli()),
+		Member: instanceId,
+	}).Err()
+	if err != nil {
+		r.logger.Error("failed save cancel all after heartbeat", slog.String("error", err.Error()))
+		return err
+	}
+	return nil
+}
+
+// Instances returns the instances that sent a heartbeat since, older ones are dropped.
+func (r *DeadlineRepository) Instances(ctx context.Context, since time.Time) ([]string, error) {
+	min := strconv.FormatInt(since.UnixMilli(), 10)
+	if err := r.redisClient.ZRemRangeByScore(ctx, cancelInstancesKey, "-inf", "("+min).Err(); err != nil {
+		r.logger.Error("failed drop stale cancel all after instances", slog.String("error", err.Error()))
+		return nil, err
+	}
+	instances, err := r.redisClient.ZRangeByScore(ctx, cancelInstancesKey, &redis.ZRangeBy{
+		Min: min,
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		r.logger.Error("failed load cancel all after instances", slog.String("error", err.Error()))
+		return nil, err
+	}
+	return instances, nil
+}
+
+// Acked reports whether instanceId already cancelled its orders for deadline.
+func (r *DeadlineRepository) Acked(ctx context.Context, deadline Deadline, instanceId string) (bool, error) {
+	acked, err := r.redisClient.SIsMember(ctx, acksKey(deadline), instanceId).Result()
+	if err != nil {
+		r.logger.Error("failed load cancel deadline ack", slog.String("error", err.Error()))
+		return false, err
+	}
+	return acked, nil
+}
+
+// Ack records that instanceId cancelled its orders for deadline.
+func (r *DeadlineRepository) Ack(ctx context.Context, deadline Deadline, instanceId string) error {
+	key := acksKey(deadline)
+	pipe := r.redisClient.TxPipeline()
+	pipe.SAdd(ctx, key, instanceId)
+	pipe.PExpire(ctx, key, cancelAcksTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		r.logger.Error("failed save cancel deadline ack", slog.String("error", err.Error()))
+		return err
+	}
+	return nil
+}
+
+// Claim removes a due deadline once every one of instances acked it, false means it
+// was pushed back, claimed already or is still waiting for an ack.
+func (r *DeadlineRepository) Claim(ctx context.Context, deadline Deadline, instances []string) (bool, error) {
+	args := make([]interface{}, 0, len(instances)+2)
+	args = append(args, deadline.UserId.String(), deadline.score)
+	for _, instance := range instances {
+		args = append(args, instance)
+	}
+	removed, err := claimDeadline.Run(ctx, r.redisClient, []string{cancelDeadlinesKey, acksKey(deadline)}, args...).Int()
+	if err != nil {
+		r.logger.Error("failed claim cancel deadline", slog.String("error", err.Error()))
+		return false, err
+	}
+	return removed == 1, nil
+}
+
+// Lead takes or extends the leadership of instanceId for ttl, only the leader claims deadlines.
+func (r *DeadlineRepository) Lead(ctx context.Context, instanceId string, ttl time.Duration) (bool, error) {
+	led, err := lead.Run(ctx, r.redisClient, []string{cancelLeaderKey}, instanceId, ttl.Milliseconds()).Int()
+	if err != nil {
+		r.logger.Error("failed take cancel all after leadership", slog.String("error", err.Error()))
+		return false, err
+	}
+	return led == 1, nil
+}
+
+func acksKey(deadline Deadline) string {
+	return cancelAcksPrefix + deadline.UserId.String() + ":" + deadline.score
+}
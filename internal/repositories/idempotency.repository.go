@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"log/slog"
+	"time"
+)
+
+// IdempotencyRecord links a client idempotency key to the request it was first used with.
+type IdempotencyRecord struct {
+	RequestHash  string             `json:"request_hash"`
+	Pending      bool               `json:"pending"`
+	OrderId      string             `json:"order_id,omitempty"`
+	Status       order.Status       `json:"status"`
+	StatusReason order.StatusReason `json:"status_reason,omitempty"`
+	StatusDetail string             `json:"status_detail,omitempty"`
+	Version      int64              `json:"version,omitempty"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+	TerminalAt   *time.Time         `json:"terminal_at,omitempty"`
+}
+
+type IdempotencyRepository struct {
+	redisClient *redis.Client
+	logger      *slog.Logger
+}
+
+func NewIdempotencyRepository(redisClient *redis.Client, logger *slog.Logger) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		redisClient: redisClient,
+		logger:      logger,
+	}
+}
+
+func idempotencyKey(userId uuid.UUID, key string) string {
+	return fmt.Sprintf("idempotency:%s:%s", userId, key)
+}
+
+// Reserve claims key for userId with a pending record that expires after ttl, short
+// so a request that never completes does not block the key for the whole window.
+// When the key is already taken it returns the stored record and false.
+func (r *IdempotencyRepository) Reserve(ctx context.Context, userId uuid.UUID, key, requestHash string, ttl time.Duration) (*IdempotencyRecord, bool, error) {
+	record := &IdempotencyRecord{RequestHash: requestHash, Pending: true}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return nil, false, err
+	}
+
+	redisKey := idempotencyKey(userId, key)
+	reserved, err := r.redisClient.SetNX(ctx, redisKey, data, ttl).Result()
+	if err != nil {
+		r.logger.Error("failed reserve idempotency key", slog.String("error", err.Error()))
+		return nil, false, err
+	}
+	if reserved {
+		return record, true, nil
+	}
+
+	stored, err := r.redisClient.Get(ctx, redisKey).Bytes()
+	if errors.Is(err, redis.Nil) {
+		//expired between SetNX and Get, try once more
+		return r.Reserve(ctx, userId, key, requestHash, ttl)
+	}
+	if err != nil {
+		r.logger.Error("failed get idempotency key", slog.String("error", err.Error()))
+		return nil, false, err
+	}
+
+	var existing IdempotencyRecord
+	if err = json.Unmarshal(stored, &existing); err != nil {
+		return nil, false, err
+	}
+	return &existing, false, nil
+}
+
+// Complete stores the outcome of the request that reserved key for ttl, the full
+// idempotency window.
+func (r *IdempotencyRepository) Complete(ctx context.Context, userId uuid.UUID, key string, record *IdempotencyRecord, ttl time.Duration) error {
+	record.Pending = false
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if err = r.redisClient.Set(ctx, idempotencyKey(userId, key), data, ttl).Err(); err != nil {
+		r.logger.Error("failed complete idempotency key", slog.String("error", err.Error()))
+		return err
+	}
+	return nil
+}
+
+// Release frees key after a failed request so the client can retry it.
+func (r *IdempotencyRepository) Release(ctx context.Context, userId uuid.UUID, key string) {
+	if err := r.redisClient.Del(ctx, idempotencyKey(userId, key)).Err(); err != nil {
+		r.logger.Error("failed release idempotency key", slog.String("error", err.Error()))
+	}
+}
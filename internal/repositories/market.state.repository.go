@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/redis/go-redis/v9"
+	"log/slog"
+)
+
+const marketStatesKey = "market_states"
+
+// MarketStateRepository persists the market trading modes in a redis hash keyed by market id.
+type MarketStateRepository struct {
+	redisClient *redis.Client
+	logger      *slog.Logger
+}
+
+func NewMarketStateRepository(redisClient *redis.Client, logger *slog.Logger) *MarketStateRepository {
+	return &MarketStateRepository{
+		redisClient: redisClient,
+		logger:      logger,
+	}
+}
+
+// Save stores state, a TRADING state is removed since it is the default.
+func (r *MarketStateRepository) Save(ctx context.Context, state models.MarketState) error {
+	if state.AcceptsOrders() {
+		if err := r.redisClient.HDel(ctx, marketStatesKey, state.MarketId.String()).Err(); err != nil {
+			r.logger.Error("failed delete market state", slog.String("error", err.Error()))
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err = r.redisClient.HSet(ctx, marketStatesKey, state.MarketId.String(), data).Err(); err != nil {
+		r.logger.Error("failed save market state", slog.String("error", err.Error()))
+		return err
+	}
+	return nil
+}
+
+// LoadAll returns every stored state, entries that do not decode are skipped.
+func (r *MarketStateRepository) LoadAll(ctx context.Context) ([]models.MarketState, error) {
+	stored, err := r.redisClient.HGetAll(ctx, marketStatesKey).Result()
+	if err != nil {
+		r.logger.Error("failed load market states", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	states := make([]models.MarketState, 0, len(stored))
+	for marketId, data := range stored {
+		var state models.MarketState
+		if err = json.Unmarshal([]byte(data), &state); err != nil {
+			r.logger.Error("failed decode market state",
+				slog.String("market_id", marketId),
+				slog.String("error", err.Error()))
+			continue
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
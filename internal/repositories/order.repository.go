@@ -3,6 +3,8 @@ package repositories
 import (
 	"errors"
 	"fmt"
+	"github.com/ewik2k21/grpcOrderService/internal/clock"
+	"github.com/ewik2k21/grpcOrderService/internal/idgen"
 	"github.com/ewik2k21/grpcOrderService/internal/models"
 	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
 	"github.com/google/uuid"
@@ -10,105 +12,623 @@ import (
 	"sync"
 )
 
+var (
+	ErrOrderNotFound       = errors.New("order not found")
+	ErrWrongUser           = errors.New("wrong user id")
+	ErrClientOrderIdInUse  = errors.New("client order id already used by a live order")
+	ErrOrderNotCancellable = errors.New("order is already in a terminal status")
+	ErrBatchAborted        = errors.New("batch aborted because another item failed")
+	ErrOrderNotTriggerable = errors.New("order is not an untriggered stop order")
+	ErrTakerNotFillable    = errors.New("taker order can not take the trade")
+	ErrMakerNotFillable    = errors.New("maker order can not take the trade")
+	ErrOrderNotAmendable   = errors.New("order is not an open limit order")
+	ErrVersionMismatch     = errors.New("order version does not match the expected version")
+	ErrTooManyOpenOrders   = errors.New("user reached the open orders limit")
+	ErrUnknownPageToken    = errors.New("page token is not an order of the user")
+)
+
+// OrderFilter selects the orders of a mass cancel or a listing, zero fields match everything.
+type OrderFilter struct {
+	UserId   uuid.UUID
+	MarketId uuid.UUID
+	Side     *order.Side
+	Status   *order.Status
+}
+
+func (f OrderFilter) match(o *models.Order) bool {
+	switch {
+	case f.UserId != uuid.Nil && o.UserId != f.UserId:
+		return false
+	case f.MarketId != uuid.Nil && o.MarketId != f.MarketId:
+		return false
+	case f.Side != nil && o.Side != *f.Side:
+		return false
+	case f.Status != nil && o.Status != *f.Status:
+		return false
+	}
+	return true
+}
+
 type IOrderRepository interface {
-	CreateOrder(order *models.Order) (uuid.UUID, *order.Status, error)
-	GetOrderStatus(userId, orderId uuid.UUID) (*order.Status, error)
+	CreateOrder(order *models.Order, maxOpenOrders int) (*uuid.UUID, *order.Status, error)
+	GetOrder(userId, orderId uuid.UUID) (*models.Order, error)
+	FindByClientOrderId(userId uuid.UUID, clientOrderId string) (uuid.UUID, error)
+	CancelOrder(userId uuid.UUID, target CancelTarget, allow func(o *models.Order) error) (*models.Order, error)
+	CreateOrders(orders []*models.Order, allOrNothing bool, maxOpenOrders int) []error
+	CancelOrders(userId uuid.UUID, targets []CancelTarget, allOrNothing bool, allow func(o *models.Order) error) ([]*models.Order, []error)
 	GetOrders() map[string]*models.Order
-	UpdateOrderStatus(orderID string, status order.Status)
-	HasChanged(orderID string) bool
-	UpdateLastState(orderID string)
-	UpdateAllOrdersToLast()
+	UpdateOrderStatus(orderID string, status order.Status, expectedVersion int64) (*models.Order, error)
+	ExpireOrder(orderId uuid.UUID, reason order.StatusReason, detail string) (*models.Order, error)
+	TriggerOrder(orderId uuid.UUID, price float64, source string) (*models.Order, error)
+	ExecuteTrade(trade *models.Trade, settle func(taker, maker models.Order) error) (*models.Order, *models.Order, error)
+	CountOpenOrders(userId uuid.UUID) int
+	MassCancel(filter OrderFilter, reason order.StatusReason, detail string, allow func(o *models.Order) bool) []*models.Order
+	AmendOrder(userId, orderId uuid.UUID, expectedVersion int64, price, quantity float64, reserve func(amended models.Order) error) (*models.Order, error)
+	NextID() uuid.UUID
+	ClientOrderIdInUse(userId uuid.UUID, clientOrderId string) bool
+	ListOrders(userId uuid.UUID, filter OrderFilter, after uuid.UUID, limit int) ([]*models.Order, bool, error)
 }
 
+var _ IOrderRepository = (*OrderRepository)(nil)
+
 type OrderRepository struct {
-	orders     map[string]*models.Order
-	lastOrders map[string]*models.Order
+	ids    idgen.Generator
+	clock  clock.Clock
+	orders map[string]*models.Order
+	// userOrders holds the order ids of each user in insertion order, orders are never
+	// removed so positions are stable and userOrderPos maps an order id to its position
+	userOrders   map[uuid.UUID][]uuid.UUID
+	userOrderPos map[uuid.UUID]int
+	// clientOrders maps user and client order id to the most recent order with it,
+	// only a live order keeps the client order id from being reused
+	clientOrders map[uuid.UUID]map[string]uuid.UUID
+	// openOrders counts the orders of each user that are not terminal
+	openOrders map[uuid.UUID]int
 	logger     *slog.Logger
 	mu         sync.RWMutex
 }
 
-func NewOrderRepository(logger *slog.Logger) *OrderRepository {
+func NewOrderRepository(ids idgen.Generator, clk clock.Clock, logger *slog.Logger) *OrderRepository {
 	return &OrderRepository{
-		orders:     make(map[string]*models.Order),
-		lastOrders: make(map[string]*models.Order),
-		logger:     logger,
+		ids:          ids,
+		clock:        clk,
+		orders:       make(map[string]*models.Order),
+		userOrders:   make(map[uuid.UUID][]uuid.UUID),
+		userOrderPos: make(map[uuid.UUID]int),
+		clientOrders: make(map[uuid.UUID]map[string]uuid.UUID),
+		openOrders:   make(map[uuid.UUID]int),
+		logger:       logger,
 	}
 }
 
-func (r *OrderRepository) CreateOrder(newOrder *models.Order) (*uuid.UUID, *order.Status, error) {
+func (r *OrderRepository) CreateOrder(newOrder *models.Order, maxOpenOrders int) (*uuid.UUID, *order.Status, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	orderId := uuid.New()
-	newOrder.Status = order.Status_CREATED
+	if err := r.insertOrder(newOrder, maxOpenOrders); err != nil {
+		return nil, nil, err
+	}
+	r.logger.Info("order successfully created")
+
+	return &newOrder.ID, &newOrder.Status, nil
+}
+
+// CreateOrders inserts a batch under a single lock and returns one error per order.
+// With allOrNothing no order is inserted unless every order can be. maxOpenOrders is
+// the open orders limit of each user, zero disables it.
+func (r *OrderRepository) CreateOrders(newOrders []*models.Order, allOrNothing bool, maxOpenOrders int) []error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	errs := make([]error, len(newOrders))
+	if allOrNothing {
+		failed := false
+		seen := make(map[uuid.UUID]map[string]struct{})
+		opened := make(map[uuid.UUID]int)
+		for i, newOrder := range newOrders {
+			if maxOpenOrders > 0 && r.openOrders[newOrder.UserId]+opened[newOrder.UserId] >= maxOpenOrders {
+				errs[i] = ErrTooManyOpenOrders
+				failed = true
+				continue
+			}
+			opened[newOrder.UserId]++
+			if newOrder.ClientOrderId == "" {
+				continue
+			}
+			_, inBatch := seen[newOrder.UserId][newOrder.ClientOrderId]
+			if r.clientOrderIdInUse(newOrder.UserId, newOrder.ClientOrderId) || inBatch {
+				errs[i] = ErrClientOrderIdInUse
+				failed = true
+				continue
+			}
+			if seen[newOrder.UserId] == nil {
+				seen[newOrder.UserId] = make(map[string]struct{})
+			}
+			seen[newOrder.UserId][newOrder.ClientOrderId] = struct{}{}
+		}
+		if failed {
+			for i := range errs {
+				if errs[i] == nil {
+					errs[i] = ErrBatchAborted
+				}
+			}
+			return errs
+		}
+	}
+
+	created := 0
+	for i, newOrder := range newOrders {
+		if errs[i] = r.insertOrder(newOrder, maxOpenOrders); errs[i] == nil {
+			created++
+		}
+	}
+	r.logger.Info("order batch created", slog.Int("created", created), slog.Int("size", len(newOrders)))
+	return errs
+}
+
+// insertOrder must be called with r.mu held. The open orders limit is checked here
+// again since the risk check counts the orders before taking the lock.
+func (r *OrderRepository) insertOrder(newOrder *models.Order, maxOpenOrders int) error {
+	orderId := newOrder.ID
+	if orderId == uuid.Nil {
+		orderId = r.ids.NewID()
+	}
 	if _, ok := r.orders[orderId.String()]; ok {
 		err := fmt.Errorf("order already created")
 		r.logger.Error("order already created", slog.String("error", err.Error()))
-		return nil, nil, err
+		return err
+	}
+	if maxOpenOrders > 0 && r.openOrders[newOrder.UserId] >= maxOpenOrders {
+		r.logger.Error("open orders limit reached", slog.String("user_id", newOrder.UserId.String()))
+		return ErrTooManyOpenOrders
 	}
 
-	r.orders[orderId.String()] = newOrder
-	r.logger.Info("order successfully created")
+	if newOrder.ClientOrderId != "" {
+		if r.clientOrderIdInUse(newOrder.UserId, newOrder.ClientOrderId) {
+			r.logger.Error("client order id in use", slog.String("client_order_id", newOrder.ClientOrderId))
+			return ErrClientOrderIdInUse
+		}
+		if r.clientOrders[newOrder.UserId] == nil {
+			r.clientOrders[newOrder.UserId] = make(map[string]uuid.UUID)
+		}
+		r.clientOrders[newOrder.UserId][newOrder.ClientOrderId] = orderId
+	}
+
+	now := r.clock.Now()
+	newOrder.ID = orderId
+	newOrder.Version = 1
+	newOrder.CreatedAt, newOrder.UpdatedAt = now, now
+	newOrder.Status = order.Status_CREATED
+	if models.IsStop(newOrder.OrderType) {
+		newOrder.Status = order.Status_UNTRIGGERED
+	}
+	newOrder.History = []models.OrderEvent{{At: now, Type: newOrder.Status.String()}}
+	//the caller keeps newOrder, the repository owns its own copy
+	stored := *newOrder
+	r.orders[orderId.String()] = &stored
+	r.openOrders[stored.UserId]++
+
+	//appended rather than sorted by id, an id taken before a concurrent insert must
+	//not land behind a page token already handed out
+	r.userOrderPos[orderId] = len(r.userOrders[stored.UserId])
+	r.userOrders[stored.UserId] = append(r.userOrders[stored.UserId], orderId)
+	return nil
+}
 
-	return &orderId, &newOrder.Status, nil
+// NextID returns the id for a new order.
+func (r *OrderRepository) NextID() uuid.UUID {
+	return r.ids.NewID()
 }
 
-func (r *OrderRepository) GetOrderStatus(userId, orderId uuid.UUID) (*order.Status, error) {
+// ListOrders returns up to limit orders of userId matching filter that were stored
+// after the order with id after, in insertion order, and whether more orders follow.
+func (r *OrderRepository) ListOrders(userId uuid.UUID, filter OrderFilter, after uuid.UUID, limit int) ([]*models.Order, bool, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	neededOrder, ok := r.orders[orderId.String()]
+
+	userOrders := r.userOrders[userId]
+	start := 0
+	if after != uuid.Nil {
+		o, ok := r.orders[after.String()]
+		if !ok || o.UserId != userId {
+			return nil, false, ErrUnknownPageToken
+		}
+		start = r.userOrderPos[after] + 1
+	}
+
+	orders := make([]*models.Order, 0, min(limit, len(userOrders)-start))
+	for _, orderId := range userOrders[start:] {
+		o := r.orders[orderId.String()]
+		if !filter.match(o) {
+			continue
+		}
+		if len(orders) == limit {
+			return orders, true, nil
+		}
+		orderCopy := *o
+		orders = append(orders, &orderCopy)
+	}
+	return orders, false, nil
+}
+
+func (r *OrderRepository) GetOrder(userId, orderId uuid.UUID) (*models.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	neededOrder, err := r.getOwnedOrder(userId, orderId)
+	if err != nil {
+		return nil, err
+	}
+	orderCopy := *neededOrder
+	return &orderCopy, nil
+}
+
+// FindByClientOrderId resolves the most recent order of userId with the given client
+// order id, the order may be terminal already.
+func (r *OrderRepository) FindByClientOrderId(userId uuid.UUID, clientOrderId string) (uuid.UUID, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	orderId, ok := r.clientOrders[userId][clientOrderId]
+	if !ok {
+		return uuid.Nil, ErrOrderNotFound
+	}
+	return orderId, nil
+}
+
+// ClientOrderIdInUse reports whether a live order of userId has clientOrderId.
+func (r *OrderRepository) ClientOrderIdInUse(userId uuid.UUID, clientOrderId string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.clientOrderIdInUse(userId, clientOrderId)
+}
+
+// clientOrderIdInUse must be called with r.mu held.
+func (r *OrderRepository) clientOrderIdInUse(userId uuid.UUID, clientOrderId string) bool {
+	orderId, ok := r.clientOrders[userId][clientOrderId]
 	if !ok {
-		err := errors.New("failed get order by orderId")
-		r.logger.Error("failed get order by orderId", slog.String("error", err.Error()))
+		return false
+	}
+	o, ok := r.orders[orderId.String()]
+	return ok && !models.IsTerminal(o.Status)
+}
+
+// CancelOrder cancels the order of userId target names, allow is checked under the
+// same lock.
+func (r *OrderRepository) CancelOrder(userId uuid.UUID, target CancelTarget, allow func(o *models.Order) error) (*models.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	neededOrder, err := r.checkCancel(userId, target, allow)
+	if err != nil {
+		return nil, err
+	}
+
+	r.setStatus(neededOrder, order.Status_CANCELLED, order.StatusReason_CANCELLED_BY_USER, "")
+	r.logger.Info("order cancelled", slog.String("order_id", neededOrder.ID.String()))
+
+	orderCopy := *neededOrder
+	return &orderCopy, nil
+}
+
+// CancelTarget is one order of a batch cancel, named by OrderId or, when OrderId is
+// uuid.Nil, by ClientOrderId. A non zero ExpectedVersion must match the order.
+type CancelTarget struct {
+	OrderId         uuid.UUID
+	ClientOrderId   string
+	ExpectedVersion int64
+}
+
+// CancelOrders cancels a batch of orders of userId under a single lock and returns
+// the cancelled orders and one error per target. Client order ids are resolved and
+// allow is checked under the same lock. With allOrNothing nothing is cancelled unless
+// every order can be.
+func (r *OrderRepository) CancelOrders(userId uuid.UUID, targets []CancelTarget, allOrNothing bool, allow func(o *models.Order) error) ([]*models.Order, []error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cancelled := make([]*models.Order, len(targets))
+	errs := make([]error, len(targets))
+	orders := make([]*models.Order, len(targets))
+	failed := false
+	seen := make(map[uuid.UUID]struct{}, len(targets))
+	for i, target := range targets {
+		orders[i], errs[i] = r.checkCancel(userId, target, allow)
+		if errs[i] == nil {
+			if _, duplicate := seen[orders[i].ID]; duplicate {
+				errs[i] = ErrOrderNotCancellable
+			}
+			seen[orders[i].ID] = struct{}{}
+		}
+		if errs[i] != nil {
+			failed = true
+		}
+	}
+	if failed && allOrNothing {
+		for i := range errs {
+			if errs[i] == nil {
+				errs[i] = ErrBatchAborted
+			}
+		}
+		return cancelled, errs
+	}
+
+	for i, neededOrder := range orders {
+		if errs[i] != nil {
+			continue
+		}
+		r.setStatus(neededOrder, order.Status_CANCELLED, order.StatusReason_CANCELLED_BY_USER, "")
+		r.logger.Info("order cancelled", slog.String("order_id", neededOrder.ID.String()))
+		orderCopy := *neededOrder
+		cancelled[i] = &orderCopy
+	}
+	return cancelled, errs
+}
+
+// checkCancel must be called with r.mu held, it resolves target to an order of userId
+// that can be cancelled. A nil allow accepts every order.
+func (r *OrderRepository) checkCancel(userId uuid.UUID, target CancelTarget, allow func(o *models.Order) error) (*models.Order, error) {
+	orderId := target.OrderId
+	if orderId == uuid.Nil {
+		resolved, ok := r.clientOrders[userId][target.ClientOrderId]
+		if !ok {
+			return nil, ErrOrderNotFound
+		}
+		orderId = resolved
+	}
+
+	neededOrder, err := r.getOwnedOrder(userId, orderId)
+	if err != nil {
 		return nil, err
 	}
+	if models.IsTerminal(neededOrder.Status) {
+		return nil, ErrOrderNotCancellable
+	}
+	if err = checkVersion(neededOrder, target.ExpectedVersion); err != nil {
+		return nil, err
+	}
+	if allow != nil {
+		if err = allow(neededOrder); err != nil {
+			return nil, err
+		}
+	}
+	return neededOrder, nil
+}
+
+// MassCancel cancels every open order that matches filter and allow under a single
+// lock for reason and returns the cancelled orders.
+func (r *OrderRepository) MassCancel(filter OrderFilter, reason order.StatusReason, detail string, allow func(o *models.Order) bool) []*models.Order {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cancelled := make([]*models.Order, 0)
+	for _, o := range r.orders {
+		if models.IsTerminal(o.Status) || !filter.match(o) || !allow(o) {
+			continue
+		}
+		r.setStatus(o, order.Status_CANCELLED, reason, detail)
+		orderCopy := *o
+		cancelled = append(cancelled, &orderCopy)
+	}
+	r.logger.Info("mass cancel", slog.Int("cancelled", len(cancelled)))
+	return cancelled
+}
+
+// checkVersion fails when expectedVersion is set and the order moved on from it.
+func checkVersion(o *models.Order, expectedVersion int64) error {
+	if expectedVersion != 0 && o.Version != expectedVersion {
+		return fmt.Errorf("%w: expected %d, order %s is at %d", ErrVersionMismatch, expectedVersion, o.ID, o.Version)
+	}
+	return nil
+}
+
+// getOwnedOrder must be called with r.mu held.
+func (r *OrderRepository) getOwnedOrder(userId, orderId uuid.UUID) (*models.Order, error) {
+	neededOrder, ok := r.orders[orderId.String()]
+	if !ok {
+		r.logger.Error("failed get order by orderId", slog.String("error", ErrOrderNotFound.Error()))
+		return nil, ErrOrderNotFound
+	}
 
 	if neededOrder.UserId != userId {
-		err := errors.New("wrong user id")
-		r.logger.Error("wrong user id in order", slog.String("error", err.Error()))
+		r.logger.Error("wrong user id in order", slog.String("error", ErrWrongUser.Error()))
+		return nil, ErrWrongUser
+	}
+	return neededOrder, nil
+}
+
+// AmendOrder changes price and quantity of an open limit order of userId at
+// expectedVersion. reserve runs with the amended order before anything changes,
+// the order stays as it was unless it succeeds.
+func (r *OrderRepository) AmendOrder(userId, orderId uuid.UUID, expectedVersion int64, price, quantity float64, reserve func(amended models.Order) error) (*models.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	neededOrder, err := r.getOwnedOrder(userId, orderId)
+	if err != nil {
+		return nil, err
+	}
+	if neededOrder.OrderType != order.OrderType_LIMIT_ORDER || models.IsTerminal(neededOrder.Status) {
+		return nil, ErrOrderNotAmendable
+	}
+	if err = checkVersion(neededOrder, expectedVersion); err != nil {
+		return nil, err
+	}
+
+	amended := *neededOrder
+	amended.Price, amended.Quantity = price, quantity
+	if amended.IsFilled() {
+		return nil, ErrOrderNotAmendable
+	}
+	if err = reserve(amended); err != nil {
 		return nil, err
 	}
 
-	return &neededOrder.Status, nil
+	at := r.clock.Now()
+	neededOrder.History = append(neededOrder.History, models.OrderEvent{
+		At:     at,
+		Type:   "AMENDED",
+		Detail: fmt.Sprintf("price %v to %v, quantity %v to %v", neededOrder.Price, price, neededOrder.Quantity, quantity),
+	})
+	neededOrder.Price, neededOrder.Quantity = price, quantity
+	neededOrder.Version++
+	neededOrder.UpdatedAt = at
+	r.logger.Info("order amended", slog.String("order_id", orderId.String()))
+
+	orderCopy := *neededOrder
+	return &orderCopy, nil
 }
 
-func (r *OrderRepository) GetOrders() map[string]*models.Order {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-	orders := make(map[string]*models.Order, len(r.orders))
-	for k, v := range r.orders {
-		orders[k] = v
+// TriggerOrder releases an untriggered stop order into normal processing.
+func (r *OrderRepository) TriggerOrder(orderId uuid.UUID, price float64, source string) (*models.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	needOrder, ok := r.orders[orderId.String()]
+	if !ok {
+		return nil, ErrOrderNotFound
 	}
-	return orders
+	if needOrder.Status != order.Status_UNTRIGGERED {
+		return nil, ErrOrderNotTriggerable
+	}
+
+	detail := fmt.Sprintf("stop price %v crossed by %s price %v", needOrder.StopPrice, source, price)
+	at := r.clock.Now()
+	needOrder.TriggeredAt = &at
+	needOrder.History = append(needOrder.History, models.OrderEvent{
+		At:     at,
+		Type:   "TRIGGERED",
+		Detail: detail,
+	})
+	r.setStatus(needOrder, order.Status_CREATED, order.StatusReason_TRIGGERED, detail)
+	r.logger.Info("stop order triggered", slog.String("order_id", orderId.String()))
+
+	orderCopy := *needOrder
+	return &orderCopy, nil
 }
 
-func (r *OrderRepository) UpdateOrderStatus(orderID string, status order.Status) {
+// ExecuteTrade fills both orders of trade under a single lock and returns the taker
+// and maker after the fill. settle runs with the orders before the fill once both can
+// take the trade, nothing changes unless it succeeds.
+func (r *OrderRepository) ExecuteTrade(trade *models.Trade, settle func(taker, maker models.Order) error) (*models.Order, *models.Order, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	needOrder := r.orders[orderID]
-	needOrder.Status = status
+
+	taker, ok := r.orders[trade.TakerOrderId().String()]
+	if !ok || !fillable(taker, trade.Quantity) {
+		return nil, nil, ErrTakerNotFillable
+	}
+	maker, ok := r.orders[trade.MakerOrderId().String()]
+	if !ok || !fillable(maker, trade.Quantity) {
+		return nil, nil, ErrMakerNotFillable
+	}
+	if err := settle(*taker, *maker); err != nil {
+		return nil, nil, err
+	}
+
+	r.fill(taker, trade)
+	r.fill(maker, trade)
+
+	takerCopy, makerCopy := *taker, *maker
+	return &takerCopy, &makerCopy, nil
+}
+
+func fillable(o *models.Order, quantity float64) bool {
+	if models.IsTerminal(o.Status) || o.Status == order.Status_UNTRIGGERED {
+		return false
+	}
+	return quantity <= o.Remaining()+models.QuantityEpsilon
+}
+
+// fill must be called with r.mu held.
+func (r *OrderRepository) fill(o *models.Order, trade *models.Trade) {
+	filled := o.FilledQuantity + trade.Quantity
+	o.AvgFillPrice = (o.AvgFillPrice*o.FilledQuantity + trade.Price*trade.Quantity) / filled
+	o.FilledQuantity = filled
+	if o.Side == order.Side_BUY {
+		o.FeeTotal += trade.BuyFee
+		o.FeeAsset = trade.BuyFeeAsset
+	} else {
+		o.FeeTotal += trade.SellFee
+		o.FeeAsset = trade.SellFeeAsset
+	}
+	o.History = append(o.History, models.OrderEvent{
+		At:     trade.ExecutedAt,
+		Type:   "FILL",
+		Detail: fmt.Sprintf("%v at %v in trade %s", trade.Quantity, trade.Price, trade.ID),
+	})
+	if o.IsFilled() {
+		r.setStatus(o, order.Status_PROCESSED, order.StatusReason_FILLED, "")
+	} else {
+		r.setStatus(o, order.Status_PROCESSING, order.StatusReason_PARTIALLY_FILLED, "")
+	}
+}
+
+// setStatus must be called with r.mu held, terminal orders free their client order id.
+func (r *OrderRepository) setStatus(o *models.Order, status order.Status, reason order.StatusReason, detail string) {
+	now := r.clock.Now()
+	o.Version++
+	o.UpdatedAt = now
+	o.StatusReason, o.StatusDetail = reason, detail
+	wasOpen, open := !models.IsTerminal(o.Status), !models.IsTerminal(status)
+	if wasOpen && !open {
+		r.openOrders[o.UserId]--
+	} else if !wasOpen && open {
+		r.openOrders[o.UserId]++
+	}
+	if o.Status != status {
+		o.History = append(o.History, models.OrderEvent{At: now, Type: status.String(), Detail: detail})
+	}
+	if !wasOpen && open {
+		o.TerminalAt = nil
+	} else if wasOpen && !open {
+		o.TerminalAt = &now
+	}
+	o.Status = status
+}
+
+// CountOpenOrders returns how many orders of userId are not terminal.
+func (r *OrderRepository) CountOpenOrders(userId uuid.UUID) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.openOrders[userId]
 }
 
-func (r *OrderRepository) HasChanged(orderID string) bool {
+func (r *OrderRepository) GetOrders() map[string]*models.Order {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	curr := r.orders[orderID]
-	last := r.lastOrders[orderID]
-	if last != nil {
-		return curr != nil
+	orders := make(map[string]*models.Order, len(r.orders))
+	for k, v := range r.orders {
+		orders[k] = v
 	}
-	return curr.Status != last.Status
+	return orders
 }
 
-func (r *OrderRepository) UpdateLastState(orderID string) {
+// UpdateOrderStatus sets the status of an order, a non zero expectedVersion must match the order.
+func (r *OrderRepository) UpdateOrderStatus(orderID string, status order.Status, expectedVersion int64) (*models.Order, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.lastOrders[orderID] = r.orders[orderID]
+	needOrder, ok := r.orders[orderID]
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+	if err := checkVersion(needOrder, expectedVersion); err != nil {
+		return nil, err
+	}
+	r.setStatus(needOrder, status, order.StatusReason_STATUS_UPDATED, "")
+
+	orderCopy := *needOrder
+	return &orderCopy, nil
 }
 
-func (r *OrderRepository) UpdateAllOrdersToLast() {
+// ExpireOrder moves a live order to EXPIRED for reason.
+func (r *OrderRepository) ExpireOrder(orderId uuid.UUID, reason order.StatusReason, detail string) (*models.Order, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	for id := range r.orders {
-		r.lastOrders[id] = r.orders[id]
+	needOrder, ok := r.orders[orderId.String()]
+	if !ok {
+		return nil, ErrOrderNotFound
 	}
+	if models.IsTerminal(needOrder.Status) {
+		return nil, ErrOrderNotCancellable
+	}
+	r.setStatus(needOrder, order.Status_EXPIRED, reason, detail)
+	r.logger.Info("order expired", slog.String("order_id", orderId.String()))
+
+	orderCopy := *needOrder
+	return &orderCopy, nil
 }
@@ -0,0 +1,230 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/ewik2k21/grpcOrderService/internal/clock"
+	"github.com/ewik2k21/grpcOrderService/internal/idgen"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestOrderRepository() *OrderRepository {
+	return NewOrderRepository(idgen.TimeOrdered{}, clock.Real{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func newLimitOrder(userId uuid.UUID) *models.Order {
+	return &models.Order{
+		UserId:    userId,
+		MarketId:  uuid.New(),
+		OrderType: order.OrderType_LIMIT_ORDER,
+		Side:      order.Side_BUY,
+		Price:     10,
+		Quantity:  1,
+	}
+}
+
+func TestCreateOrderMaxOpenOrdersConcurrent(t *testing.T) {
+	repo := newTestOrderRepository()
+	userId := uuid.New()
+
+	const limit, attempts = 5, 50
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	created := 0
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := repo.CreateOrder(newLimitOrder(userId), limit)
+			switch {
+			case err == nil:
+				mu.Lock()
+				created++
+				mu.Unlock()
+			case !errors.Is(err, ErrTooManyOpenOrders):
+				t.Errorf("unexpected error %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if created != limit {
+		t.Fatalf("created %d orders, want %d", created, limit)
+	}
+	if open := repo.CountOpenOrders(userId); open != limit {
+		t.Fatalf("open orders %d, want %d", open, limit)
+	}
+}
+
+func TestCreateOrdersMaxOpenOrders(t *testing.T) {
+	tests := []struct {
+		name         string
+		allOrNothing bool
+		stored       int
+		batch        int
+		limit        int
+		wantCreated  int
+		wantErr      error
+	}{
+		{name: "unlimited", batch: 3, wantCreated: 3},
+		{name: "within limit", stored: 1, batch: 2, limit: 3, wantCreated: 2},
+		{name: "best effort over limit", stored: 1, batch: 3, limit: 3, wantCreated: 2, wantErr: ErrTooManyOpenOrders},
+		{name: "all or nothing over limit", allOrNothing: true, stored: 1, batch: 3, limit: 3, wantErr: ErrTooManyOpenOrders},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestOrderRepository()
+			userId := uuid.New()
+			for i := 0; i < tt.stored; i++ {
+				if _, _, err := repo.CreateOrder(newLimitOrder(userId), 0); err != nil {
+					t.Fatal(err)
+				}
+			}
+			batch := make([]*models.Order, tt.batch)
+			for i := range batch {
+				batch[i] = newLimitOrder(userId)
+			}
+
+			created := 0
+			var firstErr error
+			for _, err := range repo.CreateOrders(batch, tt.allOrNothing, tt.limit) {
+				if err == nil {
+					created++
+				} else if firstErr == nil || errors.Is(firstErr, ErrBatchAborted) {
+					firstErr = err
+				}
+			}
+			if created != tt.wantCreated {
+				t.Errorf("created %d orders, want %d", created, tt.wantCreated)
+			}
+			if !errors.Is(firstErr, tt.wantErr) && !(tt.wantErr == nil && firstErr == nil) {
+				t.Errorf("error %v, want %v", firstErr, tt.wantErr)
+			}
+			if open := repo.CountOpenOrders(userId); open != tt.stored+tt.wantCreated {
+				t.Errorf("open orders %d, want %d", open, tt.stored+tt.wantCreated)
+			}
+		})
+	}
+}
+
+func TestClientOrderIdAfterTerminal(t *testing.T) {
+	repo := newTestOrderRepository()
+	userId := uuid.New()
+
+	first := newLimitOrder(userId)
+	first.ClientOrderId = "abc"
+	if _, _, err := repo.CreateOrder(first, 0); err != nil {
+		t.Fatal(err)
+	}
+	second := newLimitOrder(userId)
+	second.ClientOrderId = "abc"
+	if _, _, err := repo.CreateOrder(second, 0); !errors.Is(err, ErrClientOrderIdInUse) {
+		t.Fatalf("reuse by a live order: %v, want %v", err, ErrClientOrderIdInUse)
+	}
+
+	if _, err := repo.CancelOrder(userId, CancelTarget{OrderId: first.ID}, nil); err != nil {
+		t.Fatal(err)
+	}
+	found, err := repo.FindByClientOrderId(userId, "abc")
+	if err != nil || found != first.ID {
+		t.Fatalf("lookup of a cancelled order: %v %v, want %v", found, err, first.ID)
+	}
+
+	second = newLimitOrder(userId)
+	second.ClientOrderId = "abc"
+	if _, _, err = repo.CreateOrder(second, 0); err != nil {
+		t.Fatalf("reuse after the order ended: %v", err)
+	}
+	if found, _ = repo.FindByClientOrderId(userId, "abc"); found != second.ID {
+		t.Fatalf("lookup resolved %v, want the most recent order %v", found, second.ID)
+	}
+}
+
+func TestOrderTimestampsFollowClock(t *testing.T) {
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	repo := NewOrderRepository(idgen.TimeOrdered{}, clk, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	userId := uuid.New()
+
+	created := clk.Now()
+	orderId, _, err := repo.CreateOrder(newLimitOrder(userId), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clk.Advance(time.Minute)
+	cancelled, err := repo.CancelOrder(userId, CancelTarget{OrderId: *orderId}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cancelled.CreatedAt.Equal(created) {
+		t.Fatalf("created at %v, want %v", cancelled.CreatedAt, created)
+	}
+	if !cancelled.UpdatedAt.Equal(clk.Now()) {
+		t.Fatalf("updated at %v, want %v", cancelled.UpdatedAt, clk.Now())
+	}
+	if cancelled.TerminalAt == nil || !cancelled.TerminalAt.Equal(clk.Now()) {
+		t.Fatalf("terminal at %v, want %v", cancelled.TerminalAt, clk.Now())
+	}
+}
+
+func TestListOrdersKeepsLateInserts(t *testing.T) {
+	repo := newTestOrderRepository()
+	userId := uuid.New()
+
+	//taken first but stored last, like an id handed out before a slow insert
+	lateId := repo.NextID()
+	for i := 0; i < 2; i++ {
+		if _, _, err := repo.CreateOrder(newLimitOrder(userId), 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	first, more, err := repo.ListOrders(userId, OrderFilter{}, uuid.Nil, 1)
+	if err != nil || !more || len(first) != 1 {
+		t.Fatalf("first page %d orders, more %v, err %v", len(first), more, err)
+	}
+
+	late := newLimitOrder(userId)
+	late.ID = lateId
+	if _, _, err = repo.CreateOrder(late, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	rest, more, err := repo.ListOrders(userId, OrderFilter{}, first[0].ID, 10)
+	if err != nil || more {
+		t.Fatalf("more %v, err %v", more, err)
+	}
+	if len(rest) != 2 || rest[1].ID != lateId {
+		t.Fatalf("second page %v, want the late order last", rest)
+	}
+}
+
+func TestListOrdersUnknownPageToken(t *testing.T) {
+	repo := newTestOrderRepository()
+	userId := uuid.New()
+	orderId, _, err := repo.CreateOrder(newLimitOrder(userId), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		userId uuid.UUID
+		after  uuid.UUID
+	}{
+		{"unknown order", userId, uuid.New()},
+		{"order of another user", uuid.New(), *orderId},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := repo.ListOrders(tt.userId, OrderFilter{}, tt.after, 10); !errors.Is(err, ErrUnknownPageToken) {
+				t.Fatalf("err %v, want %v", err, ErrUnknownPageToken)
+			}
+		})
+	}
+}
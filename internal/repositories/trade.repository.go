@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/google/uuid"
+	"log/slog"
+	"sync"
+)
+
+type ITradeRepository interface {
+	AddTrade(trade *models.Trade)
+	ListTrades(userId, marketId uuid.UUID, offset, limit int) ([]*models.Trade, int)
+}
+
+// TradeRepository stores trades in execution order, indexed by user and market.
+type TradeRepository struct {
+	trades   []*models.Trade
+	byUser   map[uuid.UUID][]int
+	byMarket map[uuid.UUID][]int
+	logger   *slog.Logger
+	mu       sync.RWMutex
+}
+
+func NewTradeRepository(logger *slog.Logger) *TradeRepository {
+	return &TradeRepository{
+		byUser:   make(map[uuid.UUID][]int),
+		byMarket: make(map[uuid.UUID][]int),
+		logger:   logger,
+	}
+}
+
+func (r *TradeRepository) AddTrade(trade *models.Trade) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := len(r.trades)
+	stored := *trade
+	r.trades = append(r.trades, &stored)
+	r.byMarket[trade.MarketId] = append(r.byMarket[trade.MarketId], i)
+	r.byUser[trade.BuyUserId] = append(r.byUser[trade.BuyUserId], i)
+	if trade.SellUserId != trade.BuyUserId {
+		r.byUser[trade.SellUserId] = append(r.byUser[trade.SellUserId], i)
+	}
+}
+
+// ListTrades returns up to limit trades of userId and marketId, uuid.Nil matches any,
+// starting at offset in the index of the filter. The returned offset continues the
+// listing and is -1 when nothing is left.
+func (r *TradeRepository) ListTrades(userId, marketId uuid.UUID, offset, limit int) ([]*models.Trade, int) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var index []int
+	switch {
+	case userId != uuid.Nil:
+		index = r.byUser[userId]
+	case marketId != uuid.Nil:
+		index = r.byMarket[marketId]
+	default:
+		return nil, -1
+	}
+
+	trades := make([]*models.Trade, 0, limit)
+	for ; offset < len(index) && len(trades) < limit; offset++ {
+		trade := r.trades[index[offset]]
+		if marketId != uuid.Nil && trade.MarketId != marketId {
+			continue
+		}
+		tradeCopy := *trade
+		trades = append(trades, &tradeCopy)
+	}
+	if offset >= len(index) {
+		return trades, -1
+	}
+	return trades, offset
+}
@@ -0,0 +1,29 @@
+package resilience
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+type Backoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+// Delay returns the wait before retry number attempt (starting at 1), using
+// exponential growth capped at Max with full jitter.
+func (b Backoff) Delay(attempt int) time.Duration {
+	backoff := float64(b.Initial)
+	for i := 1; i < attempt; i++ {
+		backoff *= b.Multiplier
+		if backoff >= float64(b.Max) {
+			backoff = float64(b.Max)
+			break
+		}
+	}
+	if backoff <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(backoff) + 1))
+}
@@ -0,0 +1,48 @@
+package resilience
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		backoff Backoff
+		attempt int
+		// wantCap is the largest delay full jitter may pick
+		wantCap time.Duration
+	}{
+		{"first attempt waits up to initial", Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}, 1, 100 * time.Millisecond},
+		{"grows by the multiplier", Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}, 3, 400 * time.Millisecond},
+		{"capped at max", Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}, 5, time.Second},
+		{"large attempts stay at max", Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}, 1000, time.Second},
+		{"multiplier one stays at initial", Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 1}, 10, 100 * time.Millisecond},
+		{"zero initial never waits", Backoff{Max: time.Second, Multiplier: 2}, 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			const samples = 2000
+			seen := make(map[time.Duration]bool)
+			var longest time.Duration
+			for i := 0; i < samples; i++ {
+				delay := tt.backoff.Delay(tt.attempt)
+				if delay < 0 || delay > tt.wantCap {
+					t.Fatalf("delay %v outside [0, %v]", delay, tt.wantCap)
+				}
+				seen[delay] = true
+				longest = max(longest, delay)
+			}
+			if tt.wantCap == 0 {
+				return
+			}
+			//full jitter spreads over the whole range, not a fixed or narrow delay
+			if len(seen) < samples/2 {
+				t.Fatalf("only %d distinct delays in %d samples", len(seen), samples)
+			}
+			if longest < tt.wantCap*9/10 {
+				t.Fatalf("longest delay %v, want close to %v", longest, tt.wantCap)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package resilience
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"sync"
+	"time"
+)
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half_open"
+	case StateOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+var (
+	BreakerState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "circuit_breaker_state",
+			Help: "circuit breaker state: 0 closed, 1 half open, 2 open",
+		},
+		[]string{"name"},
+	)
+	BreakerTransitions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "circuit_breaker_transitions_total",
+			Help: "circuit breaker state transitions",
+		},
+		[]string{"name", "to"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(BreakerState, BreakerTransitions)
+}
+
+type BreakerSettings struct {
+	// FailureThreshold consecutive failures open the breaker.
+	FailureThreshold int
+	// OpenTimeout is how long the breaker stays open before letting probes through.
+	OpenTimeout time.Duration
+	// HalfOpenMaxCalls probes are allowed at once while half open.
+	HalfOpenMaxCalls int
+}
+
+// CircuitBreaker is a consecutive failures breaker with a half open probing state.
+type CircuitBreaker struct {
+	name     string
+	settings BreakerSettings
+	clock    clock.Clock
+
+	mu        sync.Mutex
+	state     State
+	failures  int
+	openedAt  time.Time
+	inFlight  int
+	successes int
+}
+
+func NewCircuitBreaker(name string, settings BreakerSettings, clk clock.Clock) *CircuitBreaker {
+	b := &CircuitBreaker{
+		name:     name,
+		settings: settings,
+		clock:    clk,
+	}
+	BreakerState.WithLabelValues(name).Set(float64(StateClosed))
+	return b
+}
+
+// Allow reports whether a call may go through. Every allowed call must be
+// followed by exactly one Done.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.clock.Now().Sub(b.openedAt) < b.settings.OpenTimeout {
+			return false
+		}
+		b.setState(StateHalfOpen)
+		fallthrough
+	case StateHalfOpen:
+		if b.inFlight >= b.settings.HalfOpenMaxCalls {
+			return false
+		}
+		b.inFlight++
+		return true
+	default:
+		return true
+	}
+}
+
+// Done records the outcome of an allowed call.
+func (b *CircuitBreaker) Done(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateHalfOpen:
+		b.inFlight--
+		if !success {
+			b.open()
+			return
+		}
+		b.successes++
+		if b.successes >= b.settings.HalfOpenMaxCalls {
+			b.failures = 0
+			b.setState(StateClosed)
+		}
+	case StateClosed:
+		if success {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.settings.FailureThreshold {
+			b.open()
+		}
+	}
+}
+
+func (b *CircuitBreaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+func (b *CircuitBreaker) open() {
+	b.openedAt = b.clock.Now()
+	b.setState(StateOpen)
+}
+
+func (b *CircuitBreaker) setState(state State) {
+	if b.state == state {
+		return
+	}
+	b.state = state
+	b.inFlight = 0
+	b.successes = 0
+	BreakerState.WithLabelValues(b.name).Set(float64(state))
+	BreakerTransitions.WithLabelValues(b.name, state.String()).Inc()
+}
@@ -0,0 +1,119 @@
+package resilience
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/clock"
+	"testing"
+	"time"
+)
+
+// breakerStep advances the clock, then either asks Allow or reports a call outcome.
+type breakerStep struct {
+	advance time.Duration
+	allow   bool
+	// wantAllowed is checked for allow steps
+	wantAllowed bool
+	// success is the outcome reported for Done steps
+	success   bool
+	wantState State
+}
+
+func allowed(want bool, state State) breakerStep {
+	return breakerStep{allow: true, wantAllowed: want, wantState: state}
+}
+
+func done(success bool, state State) breakerStep {
+	return breakerStep{success: success, wantState: state}
+}
+
+func after(d time.Duration, step breakerStep) breakerStep {
+	step.advance = d
+	return step
+}
+
+// open fails threshold calls, the breaker opens on the last one.
+func openSteps() []breakerStep {
+	return []breakerStep{
+		allowed(true, StateClosed), done(false, StateClosed),
+		allowed(true, StateClosed), done(false, StateClosed),
+		allowed(true, StateClosed), done(false, StateOpen),
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	settings := BreakerSettings{FailureThreshold: 3, OpenTimeout: 10 * time.Second, HalfOpenMaxCalls: 2}
+
+	tests := []struct {
+		name  string
+		steps []breakerStep
+	}{
+		{"failures below the threshold keep it closed", []breakerStep{
+			allowed(true, StateClosed), done(false, StateClosed),
+			allowed(true, StateClosed), done(false, StateClosed),
+		}},
+		{"a success resets the failures", []breakerStep{
+			allowed(true, StateClosed), done(false, StateClosed),
+			allowed(true, StateClosed), done(false, StateClosed),
+			allowed(true, StateClosed), done(true, StateClosed),
+			allowed(true, StateClosed), done(false, StateClosed),
+			allowed(true, StateClosed), done(false, StateClosed),
+		}},
+		{"consecutive failures open it", append(openSteps(),
+			allowed(false, StateOpen),
+		)},
+		{"stays open until the timeout", append(openSteps(),
+			after(9*time.Second, allowed(false, StateOpen)),
+			after(999*time.Millisecond, allowed(false, StateOpen)),
+		)},
+		{"half open allows up to the probe limit", append(openSteps(),
+			after(10*time.Second, allowed(true, StateHalfOpen)),
+			allowed(true, StateHalfOpen),
+			allowed(false, StateHalfOpen),
+		)},
+		{"a finished probe frees its slot", append(openSteps(),
+			after(10*time.Second, allowed(true, StateHalfOpen)),
+			allowed(true, StateHalfOpen),
+			done(true, StateHalfOpen),
+			allowed(true, StateHalfOpen),
+		)},
+		{"enough successful probes close it", append(openSteps(),
+			after(10*time.Second, allowed(true, StateHalfOpen)),
+			allowed(true, StateHalfOpen),
+			done(true, StateHalfOpen),
+			done(true, StateClosed),
+			allowed(true, StateClosed),
+		)},
+		{"a failed probe opens it again", append(openSteps(),
+			after(10*time.Second, allowed(true, StateHalfOpen)),
+			done(false, StateOpen),
+			allowed(false, StateOpen),
+			after(10*time.Second, allowed(true, StateHalfOpen)),
+		)},
+		{"closing again needs the full threshold to reopen", append(openSteps(),
+			after(10*time.Second, allowed(true, StateHalfOpen)),
+			allowed(true, StateHalfOpen),
+			done(true, StateHalfOpen),
+			done(true, StateClosed),
+			allowed(true, StateClosed), done(false, StateClosed),
+			allowed(true, StateClosed), done(false, StateClosed),
+		)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			breaker := NewCircuitBreaker("test_"+t.Name(), settings, clk)
+			for i, step := range tt.steps {
+				clk.Advance(step.advance)
+				if step.allow {
+					if got := breaker.Allow(); got != step.wantAllowed {
+						t.Fatalf("step %d: Allow = %v, want %v", i, got, step.wantAllowed)
+					}
+				} else {
+					breaker.Done(step.success)
+				}
+				if state := breaker.State(); state != step.wantState {
+					t.Fatalf("step %d: state %v, want %v", i, state, step.wantState)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package risk
+
+import (
+	"fmt"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"math"
+)
+
+// Reasons of the built-in checks, attached to rejected requests.
+const (
+	ReasonMaxQuantity   = "RISK_MAX_ORDER_QUANTITY"
+	ReasonMaxNotional   = "RISK_MAX_ORDER_NOTIONAL"
+	ReasonMaxOpenOrders = "RISK_MAX_OPEN_ORDERS"
+	ReasonPriceBand     = "RISK_PRICE_BAND"
+)
+
+// DefaultChecks returns the built-in chain.
+func DefaultChecks() []Check {
+	return []Check{
+		MaxOrderQuantity{},
+		MaxOrderNotional{},
+		MaxOpenOrders{},
+		PriceBand{},
+	}
+}
+
+type MaxOrderQuantity struct{}
+
+func (MaxOrderQuantity) Name() string { return "max_order_quantity" }
+
+func (MaxOrderQuantity) Check(in *Input) *Rejection {
+	limit := in.Limits.ForMarket(in.Order.MarketId).MaxOrderQuantity
+	if limit > 0 && in.Order.Quantity > limit {
+		return &Rejection{
+			Reason:  ReasonMaxQuantity,
+			Message: fmt.Sprintf("quantity %v exceeds the limit of %v", in.Order.Quantity, limit),
+		}
+	}
+	return nil
+}
+
+// MaxOrderNotional prices market orders at the reference price and lets them
+// pass while no reference price is known.
+type MaxOrderNotional struct{}
+
+func (MaxOrderNotional) Name() string { return "max_order_notional" }
+
+func (MaxOrderNotional) Check(in *Input) *Rejection {
+	limit := in.Limits.ForMarket(in.Order.MarketId).MaxOrderNotional
+	if limit <= 0 {
+		return nil
+	}
+	price := in.Order.Price
+	if !hasLimitPrice(in.Order) {
+		price = in.ReferencePrice
+	}
+	if notional := price * in.Order.Quantity; notional > limit {
+		return &Rejection{
+			Reason:  ReasonMaxNotional,
+			Message: fmt.Sprintf("notional %v exceeds the limit of %v", notional, limit),
+		}
+	}
+	return nil
+}
+
+type MaxOpenOrders struct{}
+
+func (MaxOpenOrders) Name() string { return "max_open_orders" }
+
+func (MaxOpenOrders) Check(in *Input) *Rejection {
+	limit := in.Limits.MaxOpenOrders
+	if limit > 0 && in.OpenOrders >= limit {
+		return &Rejection{
+			Reason:  ReasonMaxOpenOrders,
+			Message: fmt.Sprintf("user already has %d open orders, the limit is %d", in.OpenOrders, limit),
+		}
+	}
+	return nil
+}
+
+// PriceBand rejects limit prices too far from the reference price, it passes while
+// no reference price is known.
+type PriceBand struct{}
+
+func (PriceBand) Name() string { return "price_band" }
+
+func (PriceBand) Check(in *Input) *Rejection {
+	band := in.Limits.PriceBand
+	if band <= 0 || in.ReferencePrice <= 0 || !hasLimitPrice(in.Order) {
+		return nil
+	}
+	if distance := math.Abs(in.Order.Price-in.ReferencePrice) / in.ReferencePrice; distance > band {
+		return &Rejection{
+			Reason: ReasonPriceBand,
+			Message: fmt.Sprintf("price %v is outside the band of %v around the reference price %v",
+				in.Order.Price, band, in.ReferencePrice),
+		}
+	}
+	return nil
+}
+
+func hasLimitPrice(o *models.Order) bool {
+	return o.OrderType == order.OrderType_LIMIT_ORDER || o.OrderType == order.OrderType_STOP_LIMIT
+}
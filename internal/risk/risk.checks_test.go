@@ -0,0 +1,60 @@
+package risk
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestEngineEvaluate(t *testing.T) {
+	marketId := uuid.New()
+	defaults := Limits{
+		MaxOrderQuantity: 10,
+		MaxOrderNotional: 1000,
+		MaxOpenOrders:    2,
+		PriceBand:        0.1,
+		Markets: map[uuid.UUID]MarketLimits{
+			marketId: {MaxOrderQuantity: 5, MaxOrderNotional: 1000},
+		},
+	}
+	engine := NewEngine(defaults, nil, DefaultChecks()...)
+
+	limit := func(price, quantity float64) *models.Order {
+		return &models.Order{OrderType: order.OrderType_LIMIT_ORDER, Price: price, Quantity: quantity}
+	}
+	tests := []struct {
+		name       string
+		order      *models.Order
+		openOrders int
+		reference  float64
+		want       string
+	}{
+		{name: "accepted", order: limit(10, 1)},
+		{name: "quantity", order: limit(10, 11), want: ReasonMaxQuantity},
+		{name: "market quantity override", order: &models.Order{OrderType: order.OrderType_LIMIT_ORDER, MarketId: marketId, Price: 10, Quantity: 6}, want: ReasonMaxQuantity},
+		{name: "notional", order: limit(200, 6), want: ReasonMaxNotional},
+		{name: "open orders", order: limit(10, 1), openOrders: 2, want: ReasonMaxOpenOrders},
+		{name: "price band", order: limit(12, 1), reference: 10, want: ReasonPriceBand},
+		{name: "price band without reference", order: limit(12, 1)},
+		{name: "first failing check wins", order: limit(200, 11), openOrders: 2, want: ReasonMaxQuantity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rejection := engine.Evaluate(&Input{
+				Role:           pkg.UserRole_USER_ROLE_ADMIN,
+				Order:          tt.order,
+				OpenOrders:     tt.openOrders,
+				ReferencePrice: tt.reference,
+			})
+			got := ""
+			if rejection != nil {
+				got = rejection.Reason
+			}
+			if got != tt.want {
+				t.Fatalf("rejection %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
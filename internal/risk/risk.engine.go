@@ -0,0 +1,138 @@
+package risk
+
+import (
+	"fmt"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"sync"
+)
+
+var (
+	Rejections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "risk_rejections_total",
+			Help: "orders rejected by pre-trade risk checks",
+		},
+		[]string{"check"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(Rejections)
+}
+
+// Limits are the risk limits of one user role, zero disables a limit.
+type Limits struct {
+	MaxOrderQuantity float64
+	MaxOrderNotional float64
+	MaxOpenOrders    int
+	// PriceBand is the allowed relative distance of a limit price from the reference price
+	PriceBand float64
+	// Markets override the order size limits per market
+	Markets map[uuid.UUID]MarketLimits
+}
+
+type MarketLimits struct {
+	MaxOrderQuantity float64
+	MaxOrderNotional float64
+}
+
+// ForMarket returns the order size limits that apply in marketId.
+func (l Limits) ForMarket(marketId uuid.UUID) MarketLimits {
+	if market, ok := l.Markets[marketId]; ok {
+		return market
+	}
+	return MarketLimits{MaxOrderQuantity: l.MaxOrderQuantity, MaxOrderNotional: l.MaxOrderNotional}
+}
+
+// Input is what the checks see of an order about to be accepted.
+type Input struct {
+	Role  pkg.UserRole
+	Order *models.Order
+	// OpenOrders of the user before this order
+	OpenOrders int
+	// ReferencePrice of the market, 0 when none is known
+	ReferencePrice float64
+	Limits         Limits
+}
+
+// Rejection is the error of a failed check.
+type Rejection struct {
+	Check   string
+	Reason  string
+	Message string
+}
+
+func (r *Rejection) Error() string {
+	return fmt.Sprintf("risk check %s: %s", r.Check, r.Message)
+}
+
+// Check is one link of the risk chain, it returns nil when the order passes.
+type Check interface {
+	Name() string
+	Check(in *Input) *Rejection
+}
+
+// Engine runs a chain of checks with limits per user role.
+type Engine struct {
+	checks []Check
+
+	mu       sync.RWMutex
+	defaults Limits
+	roles    map[pkg.UserRole]Limits
+}
+
+func NewEngine(defaults Limits, roles map[pkg.UserRole]Limits, checks ...Check) *Engine {
+	return &Engine{
+		checks:   checks,
+		defaults: defaults,
+		roles:    roles,
+	}
+}
+
+// SetLimits replaces the limits, roles without an entry use defaults.
+func (e *Engine) SetLimits(defaults Limits, roles map[pkg.UserRole]Limits) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.defaults = defaults
+	e.roles = roles
+}
+
+func (e *Engine) Limits(role pkg.UserRole) Limits {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if limits, ok := e.roles[role]; ok {
+		return limits
+	}
+	return e.defaults
+}
+
+// Evaluate runs the checks in order and returns the first rejection.
+// in.Limits is filled from the role of the input.
+func (e *Engine) Evaluate(in *Input) *Rejection {
+	in.Limits = e.Limits(in.Role)
+	for _, check := range e.checks {
+		if rejection := check.Check(in); rejection != nil {
+			rejection.Check = check.Name()
+			Rejections.WithLabelValues(check.Name()).Inc()
+			return rejection
+		}
+	}
+	return nil
+}
+
+// EvaluateAll runs every check and returns all rejections. It serves dry runs, the
+// rejections are not counted.
+func (e *Engine) EvaluateAll(in *Input) []*Rejection {
+	in.Limits = e.Limits(in.Role)
+	var rejections []*Rejection
+	for _, check := range e.checks {
+		if rejection := check.Check(in); rejection != nil {
+			rejection.Check = check.Name()
+			rejections = append(rejections, rejection)
+		}
+	}
+	return rejections
+}
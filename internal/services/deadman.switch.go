@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"github.com/ewik2k21/grpcOrderService/internal/clock"
+	"github.com/ewik2k21/grpcOrderService/internal/repositories"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"log/slog"
+	"time"
+)
+
+var (
+	CancelAllAfterLeader = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "cancel_all_after_leader",
+			Help: "1 while this instance claims the cancel all after deadlines",
+		},
+	)
+	CancelAllAfterTriggered = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "cancel_all_after_triggered_total",
+			Help: "cancel all after deadlines that ran out",
+		},
+	)
+	CancelAllAfterOrders = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "cancel_all_after_orders_total",
+			Help: "orders cancelled because a cancel all after deadline ran out",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(CancelAllAfterLeader, CancelAllAfterTriggered, CancelAllAfterOrders)
+}
+
+type DeadManPolicy struct {
+	// MaxTimeout is the longest timeout a client may arm
+	MaxTimeout time.Duration
+	// PollInterval is how often every instance looks for due deadlines
+	PollInterval time.Duration
+	// LeaderTTL is how long leadership outlives a leader that stopped renewing it, and
+	// how long an instance that stopped polling still counts as live
+	LeaderTTL time.Duration
+}
+
+// DeadManSwitch cancels the open orders of a user whose cancel all after deadline
+// ran out. Deadlines live in redis, every instance fires them against the orders it
+// holds and acks them, and a single leader removes a deadline once all live
+// instances acked it.
+type DeadManSwitch struct {
+	repo       *repositories.DeadlineRepository
+	clock      clock.Clock
+	policy     DeadManPolicy
+	instanceId string
+	cancel     func(userId uuid.UUID) int
+	logger     *slog.Logger
+}
+
+func NewDeadManSwitch(repo *repositories.DeadlineRepository, clk clock.Clock, policy DeadManPolicy, logger *slog.Logger, cancel func(userId uuid.UUID) int) *DeadManSwitch {
+	return &DeadManSwitch{
+		repo:       repo,
+		clock:      clk,
+		policy:     policy,
+		instanceId: uuid.NewString(),
+		cancel:     cancel,
+		logger:     logger,
+	}
+}
+
+// Arm moves the deadline of userId to timeout from now, a zero timeout disarms it.
+func (d *DeadManSwitch) Arm(ctx context.Context, userId uuid.UUID, timeout time.Duration) (time.Time, error) {
+	if timeout == 0 {
+		return time.Time{}, d.repo.Clear(ctx, userId)
+	}
+	at := d.clock.Now().Add(timeout)
+	return at, d.repo.Set(ctx, userId, at)
+}
+
+// Run polls for due deadlines until ctx is done.
+func (d *DeadManSwitch) Run(ctx context.Context) {
+	defer CancelAllAfterLeader.Set(0)
+	for {
+		d.poll(ctx)
+
+		timer := d.clock.NewTimer(d.policy.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+	}
+}
+
+func (d *DeadManSwitch) poll(ctx context.Context) {
+	now := d.clock.Now()
+	if err := d.repo.Heartbeat(ctx, d.instanceId, now); err != nil {
+		return
+	}
+	due, err := d.repo.Due(ctx, now)
+	if err != nil {
+		return
+	}
+	for _, deadline := range due {
+		d.fire(ctx, deadline)
+	}
+
+	leader, err := d.repo.Lead(ctx, d.instanceId, d.policy.LeaderTTL)
+	if err != nil || !leader {
+		CancelAllAfterLeader.Set(0)
+		return
+	}
+	CancelAllAfterLeader.Set(1)
+	if len(due) == 0 {
+		return
+	}
+
+	instances, err := d.repo.Instances(ctx, now.Add(-d.policy.LeaderTTL))
+	if err != nil {
+		return
+	}
+	for _, deadline := range due {
+		//the deadline stays until every instance holding orders of the user cancelled them
+		_, _ = d.repo.Claim(ctx, deadline, instances)
+	}
+}
+
+// fire cancels the orders this instance holds for a due deadline once and acks it.
+func (d *DeadManSwitch) fire(ctx context.Context, deadline repositories.Deadline) {
+	acked, err := d.repo.Acked(ctx, deadline, d.instanceId)
+	if err != nil || acked {
+		return
+	}
+
+	cancelled := d.cancel(deadline.UserId)
+	CancelAllAfterTriggered.Inc()
+	CancelAllAfterOrders.Add(float64(cancelled))
+	d.logger.Warn("cancel all after deadline ran out",
+		slog.String("user_id", deadline.UserId.String()),
+		slog.Time("deadline", deadline.At),
+		slog.Int("cancelled", cancelled))
+
+	_ = d.repo.Ack(ctx, deadline, d.instanceId)
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/ewik2k21/grpcOrderService/internal/clock"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+var (
+	CancelOnDisconnect = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cancel_on_disconnect_total",
+			Help: "ended cancel on disconnect sessions by outcome",
+		},
+		[]string{"outcome"},
+	)
+	CancelOnDisconnectOrders = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "cancel_on_disconnect_orders_total",
+			Help: "orders cancelled because their cancel on disconnect session dropped",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(CancelOnDisconnect, CancelOnDisconnectOrders)
+}
+
+var errSessionNotFound = errors.New("session not found")
+
+type disconnectSession struct {
+	userId   uuid.UUID
+	graceful bool
+}
+
+// DisconnectGuard cancels the open orders of a user once a cancel on disconnect
+// session ends without a graceful close and the user does not reconnect within
+// the grace period.
+type DisconnectGuard struct {
+	clock  clock.Clock
+	grace  time.Duration
+	cancel func(userId uuid.UUID) int
+	logger *slog.Logger
+
+	mu       sync.Mutex
+	stopped  bool
+	sessions map[uuid.UUID]*disconnectSession
+	// pending holds the abort channel of the scheduled cancel of each user
+	pending map[uuid.UUID]chan struct{}
+}
+
+func NewDisconnectGuard(clk clock.Clock, grace time.Duration, logger *slog.Logger, cancel func(userId uuid.UUID) int) *DisconnectGuard {
+	return &DisconnectGuard{
+		clock:    clk,
+		grace:    grace,
+		cancel:   cancel,
+		logger:   logger,
+		sessions: make(map[uuid.UUID]*disconnectSession),
+		pending:  make(map[uuid.UUID]chan struct{}),
+	}
+}
+
+// Run waits for ctx, then drops pending cancels: a service shutdown is no client disconnect.
+func (g *DisconnectGuard) Run(ctx context.Context) {
+	<-ctx.Done()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.stopped = true
+	for userId, abort := range g.pending {
+		close(abort)
+		delete(g.pending, userId)
+	}
+}
+
+// Open starts a session of userId and aborts a cancel still waiting for the user to come back.
+func (g *DisconnectGuard) Open(userId uuid.UUID) uuid.UUID {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if abort, ok := g.pending[userId]; ok {
+		close(abort)
+		delete(g.pending, userId)
+		CancelOnDisconnect.WithLabelValues("reconnected").Inc()
+		g.logger.Info("cancel on disconnect aborted by reconnect", slog.String("user_id", userId.String()))
+	}
+
+	sessionId := uuid.New()
+	g.sessions[sessionId] = &disconnectSession{userId: userId}
+	return sessionId
+}
+
+// Close marks a session of userId as closed on purpose, its end then cancels nothing.
+func (g *DisconnectGuard) Close(userId, sessionId uuid.UUID) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	session, ok := g.sessions[sessionId]
+	if !ok || session.userId != userId {
+		return errSessionNotFound
+	}
+	session.graceful = true
+	return nil
+}
+
+// End is called when the stream of a session finished, dropped tells whether the
+// client went away. A stream the server closed ends the session gracefully.
+func (g *DisconnectGuard) End(sessionId uuid.UUID, dropped bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	session, ok := g.sessions[sessionId]
+	if !ok {
+		return
+	}
+	delete(g.sessions, sessionId)
+
+	switch {
+	case !dropped || session.graceful || g.stopped:
+		CancelOnDisconnect.WithLabelValues("graceful").Inc()
+		return
+	case g.hasSession(session.userId):
+		//another connection of the user is still up
+		CancelOnDisconnect.WithLabelValues("still_connected").Inc()
+		return
+	}
+	if _, ok = g.pending[session.userId]; ok {
+		return
+	}
+
+	abort := make(chan struct{})
+	g.pending[session.userId] = abort
+	g.logger.Warn("cancel on disconnect session dropped",
+		slog.String("user_id", session.userId.String()),
+		slog.Duration("grace", g.grace))
+	go g.wait(session.userId, abort)
+}
+
+// hasSession must be called with g.mu held.
+func (g *DisconnectGuard) hasSession(userId uuid.UUID) bool {
+	for _, session := range g.sessions {
+		if session.userId == userId {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *DisconnectGuard) wait(userId uuid.UUID, abort chan struct{}) {
+	timer := g.clock.NewTimer(g.grace)
+	defer timer.Stop()
+
+	select {
+	case <-abort:
+		return
+	case <-timer.C():
+	}
+
+	g.mu.Lock()
+	if g.pending[userId] != abort {
+		g.mu.Unlock()
+		return
+	}
+	delete(g.pending, userId)
+	g.mu.Unlock()
+
+	cancelled := g.cancel(userId)
+	CancelOnDisconnect.WithLabelValues("cancelled").Inc()
+	CancelOnDisconnectOrders.Add(float64(cancelled))
+	g.logger.Warn("cancelled orders on disconnect",
+		slog.String("user_id", userId.String()),
+		slog.Int("cancelled", cancelled))
+}
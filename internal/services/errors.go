@@ -0,0 +1,95 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ewik2k21/grpcOrderService/internal/ledger"
+	"github.com/ewik2k21/grpcOrderService/internal/repositories"
+	"github.com/ewik2k21/grpcOrderService/internal/risk"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+const errorDomain = "order-service"
+
+// Reasons attached as errdetails.ErrorInfo to FailedPrecondition errors.
+const (
+	ReasonMarketDisabled = "MARKET_DISABLED"
+	ReasonMarketDeleted  = "MARKET_DELETED"
+	ReasonMarketAssets   = "MARKET_ASSETS_UNKNOWN"
+	ReasonNoPrice        = "NO_REFERENCE_PRICE"
+	ReasonNoFunds        = "INSUFFICIENT_FUNDS"
+	ReasonMarketHalted   = "MARKET_HALTED"
+	ReasonCancelOnly     = "MARKET_CANCEL_ONLY"
+)
+
+func failedPrecondition(reason, format string, args ...any) error {
+	st := status.New(codes.FailedPrecondition, fmt.Sprintf(format, args...))
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: errorDomain,
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// RefusalReason maps the error a new order was refused with to its status reason,
+// NO_REASON when the refusal has none of its own.
+func RefusalReason(err error) order.StatusReason {
+	for _, detail := range status.Convert(err).Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != errorDomain {
+			continue
+		}
+		switch reason := info.GetReason(); {
+		case strings.HasPrefix(reason, "RISK_"):
+			return order.StatusReason_REJECTED_BY_RISK
+		case reason == ReasonNoFunds:
+			return order.StatusReason_REJECTED_INSUFFICIENT_FUNDS
+		case reason == ReasonMarketHalted, reason == ReasonCancelOnly:
+			return order.StatusReason_REJECTED_MARKET_HALTED
+		}
+	}
+	return order.StatusReason_NO_REASON
+}
+
+// ledgerError converts ledger errors into grpc status errors.
+func ledgerError(err error) error {
+	switch {
+	case errors.Is(err, ledger.ErrInsufficientFunds):
+		return failedPrecondition(ReasonNoFunds, "%s", err.Error())
+	case errors.Is(err, ledger.ErrInvalidAmount):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// repoError converts repository errors into grpc status errors.
+func repoError(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrOrderNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, repositories.ErrWrongUser):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, repositories.ErrClientOrderIdInUse):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, repositories.ErrOrderNotCancellable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, repositories.ErrBatchAborted), errors.Is(err, repositories.ErrVersionMismatch):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, repositories.ErrOrderNotAmendable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, repositories.ErrUnknownPageToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, repositories.ErrTooManyOpenOrders):
+		return failedPrecondition(risk.ReasonMaxOpenOrders, "%s", err.Error())
+	default:
+		return err
+	}
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"errors"
+	"github.com/ewik2k21/grpcOrderService/internal/risk"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestRefusalReason(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want order.StatusReason
+	}{
+		{"risk", failedPrecondition(risk.ReasonMaxNotional, "too big"), order.StatusReason_REJECTED_BY_RISK},
+		{"open orders", failedPrecondition(risk.ReasonMaxOpenOrders, "too many"), order.StatusReason_REJECTED_BY_RISK},
+		{"funds", failedPrecondition(ReasonNoFunds, "broke"), order.StatusReason_REJECTED_INSUFFICIENT_FUNDS},
+		{"halted", failedPrecondition(ReasonMarketHalted, "halted"), order.StatusReason_REJECTED_MARKET_HALTED},
+		{"cancel only", failedPrecondition(ReasonCancelOnly, "cancel only"), order.StatusReason_REJECTED_MARKET_HALTED},
+		{"other reason", failedPrecondition(ReasonMarketDisabled, "disabled"), order.StatusReason_NO_REASON},
+		{"no details", status.Error(codes.InvalidArgument, "bad"), order.StatusReason_NO_REASON},
+		{"plain error", errors.New("boom"), order.StatusReason_NO_REASON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RefusalReason(tt.err); got != tt.want {
+				t.Fatalf("RefusalReason = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
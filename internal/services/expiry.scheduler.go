@@ -0,0 +1,107 @@
+package services
+
+import (
+	"container/heap"
+	"context"
+	"github.com/ewik2k21/grpcOrderService/internal/clock"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+// ExpiryScheduler calls expire for every scheduled order once its deadline passes.
+type ExpiryScheduler struct {
+	clock  clock.Clock
+	expire func(orderId uuid.UUID)
+
+	mu      sync.Mutex
+	pending expiryHeap
+	wake    chan struct{}
+}
+
+func NewExpiryScheduler(clk clock.Clock, expire func(orderId uuid.UUID)) *ExpiryScheduler {
+	return &ExpiryScheduler{
+		clock:  clk,
+		expire: expire,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+func (e *ExpiryScheduler) Schedule(orderId uuid.UUID, expireAt time.Time) {
+	e.mu.Lock()
+	heap.Push(&e.pending, expiryItem{orderId: orderId, expireAt: expireAt})
+	e.mu.Unlock()
+
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run expires due orders until ctx is done.
+func (e *ExpiryScheduler) Run(ctx context.Context) {
+	for {
+		for _, orderId := range e.popDue() {
+			e.expire(orderId)
+		}
+
+		var timer clock.Timer
+		var fired <-chan time.Time
+		if next, ok := e.next(); ok {
+			timer = e.clock.NewTimer(next.Sub(e.clock.Now()))
+			fired = timer.C()
+		}
+
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		case <-e.wake:
+		case <-fired:
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+func (e *ExpiryScheduler) popDue() []uuid.UUID {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.clock.Now()
+	var due []uuid.UUID
+	for e.pending.Len() > 0 && !e.pending[0].expireAt.After(now) {
+		due = append(due, heap.Pop(&e.pending).(expiryItem).orderId)
+	}
+	return due
+}
+
+func (e *ExpiryScheduler) next() (time.Time, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.pending.Len() == 0 {
+		return time.Time{}, false
+	}
+	return e.pending[0].expireAt, true
+}
+
+type expiryItem struct {
+	orderId  uuid.UUID
+	expireAt time.Time
+}
+
+type expiryHeap []expiryItem
+
+func (h expiryHeap) Len() int           { return len(h) }
+func (h expiryHeap) Less(i, j int) bool { return h[i].expireAt.Before(h[j].expireAt) }
+func (h expiryHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *expiryHeap) Push(x any)        { *h = append(*h, x.(expiryItem)) }
+func (h *expiryHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
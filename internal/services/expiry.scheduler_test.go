@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"github.com/ewik2k21/grpcOrderService/internal/clock"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+func startScheduler(t *testing.T) (*ExpiryScheduler, *clock.Fake, <-chan uuid.UUID) {
+	t.Helper()
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	expired := make(chan uuid.UUID, 16)
+	scheduler := NewExpiryScheduler(clk, func(orderId uuid.UUID) {
+		expired <- orderId
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return scheduler, clk, expired
+}
+
+// waitTimers waits until the scheduler armed its timer.
+func waitTimers(t *testing.T, clk *clock.Fake, want int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for clk.Timers() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("scheduler armed %d timers, want %d", clk.Timers(), want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func expectExpired(t *testing.T, expired <-chan uuid.UUID, want uuid.UUID) {
+	t.Helper()
+	select {
+	case got := <-expired:
+		if got != want {
+			t.Fatalf("expired %s, want %s", got, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("%s did not expire", want)
+	}
+}
+
+func expectNone(t *testing.T, expired <-chan uuid.UUID) {
+	t.Helper()
+	select {
+	case got := <-expired:
+		t.Fatalf("%s expired early", got)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestExpirySchedulerWaitsForDeadline(t *testing.T) {
+	scheduler, clk, expired := startScheduler(t)
+	orderId := uuid.New()
+	scheduler.Schedule(orderId, clk.Now().Add(5*time.Second))
+	waitTimers(t, clk, 1)
+
+	clk.Advance(4 * time.Second)
+	expectNone(t, expired)
+
+	clk.Advance(time.Second)
+	expectExpired(t, expired, orderId)
+}
+
+func TestExpirySchedulerExpiresInDeadlineOrder(t *testing.T) {
+	scheduler, clk, expired := startScheduler(t)
+	later, sooner := uuid.New(), uuid.New()
+	scheduler.Schedule(later, clk.Now().Add(2*time.Second))
+	scheduler.Schedule(sooner, clk.Now().Add(time.Second))
+	waitTimers(t, clk, 1)
+
+	clk.Advance(time.Second)
+	expectExpired(t, expired, sooner)
+	expectNone(t, expired)
+
+	waitTimers(t, clk, 1)
+	clk.Advance(time.Second)
+	expectExpired(t, expired, later)
+}
+
+func TestExpirySchedulerEarlierDeadlineRearms(t *testing.T) {
+	scheduler, clk, expired := startScheduler(t)
+	later, sooner := uuid.New(), uuid.New()
+	scheduler.Schedule(later, clk.Now().Add(time.Hour))
+	waitTimers(t, clk, 1)
+
+	scheduler.Schedule(sooner, clk.Now().Add(time.Second))
+	clk.Advance(time.Second)
+	expectExpired(t, expired, sooner)
+	expectNone(t, expired)
+}
+
+func TestExpirySchedulerPastDeadline(t *testing.T) {
+	scheduler, clk, expired := startScheduler(t)
+	orderId := uuid.New()
+	scheduler.Schedule(orderId, clk.Now().Add(-time.Second))
+	expectExpired(t, expired, orderId)
+}
+
+func TestExpirySchedulerSameDeadline(t *testing.T) {
+	scheduler, clk, expired := startScheduler(t)
+	first, second := uuid.New(), uuid.New()
+	at := clk.Now().Add(time.Minute)
+	scheduler.Schedule(first, at)
+	scheduler.Schedule(second, at)
+	waitTimers(t, clk, 1)
+
+	clk.Advance(time.Minute)
+	got := map[uuid.UUID]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case orderId := <-expired:
+			got[orderId] = true
+		case <-time.After(time.Second):
+			t.Fatal("orders with the same deadline did not expire")
+		}
+	}
+	if !got[first] || !got[second] {
+		t.Fatalf("expired %v, want %s and %s", got, first, second)
+	}
+}
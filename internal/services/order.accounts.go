@@ -0,0 +1,132 @@
+package services
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/ledger"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+)
+
+// reserveFunds assigns the order id and reserves what the order can spend.
+func (s *OrderService) reserveFunds(market *models.Market, o *models.Order) error {
+	asset, amount, err := s.reservation(market, o)
+	if err != nil {
+		return err
+	}
+	o.ID = s.repo.NextID()
+	return s.reserve(o, asset, amount)
+}
+
+// reservation is what an order can spend: the base asset for sells and the quote
+// asset at the reservation price for buys.
+func (s *OrderService) reservation(market *models.Market, o *models.Order) (string, float64, error) {
+	base, quote, ok := market.Assets()
+	if !ok {
+		return "", 0, failedPrecondition(ReasonMarketAssets, "market %s has no base/quote asset pair in its name %q", market.ID, market.Name)
+	}
+	if o.Side == order.Side_SELL {
+		return base, o.Quantity, nil
+	}
+
+	price, err := s.reservationPrice(o)
+	if err != nil {
+		return "", 0, err
+	}
+	return quote, price * o.Quantity, nil
+}
+
+func (s *OrderService) reserve(o *models.Order, asset string, amount float64) error {
+	if err := s.ledger.Reserve(o.ID, o.UserId, asset, amount); err != nil {
+		s.logger.Warn("failed reserve funds",
+			slog.String("user_id", o.UserId.String()),
+			slog.String("asset", asset),
+			slog.String("error", err.Error()))
+		return ledgerError(err)
+	}
+	return nil
+}
+
+// reservationPrice is the price a buy order is expected to pay at most: its limit
+// price, the stop price of a stop market order or the reference price of a market order.
+func (s *OrderService) reservationPrice(o *models.Order) (float64, error) {
+	switch o.OrderType {
+	case order.OrderType_LIMIT_ORDER, order.OrderType_STOP_LIMIT:
+		return o.Price, nil
+	case order.OrderType_STOP_MARKET:
+		return o.StopPrice, nil
+	}
+	price, ok := s.prices.Reference(o.MarketId)
+	if !ok {
+		return 0, failedPrecondition(ReasonNoPrice, "market %s has no reference price to reserve a market buy against", o.MarketId)
+	}
+	return price.Value, nil
+}
+
+// orderAssets returns the base and quote asset of the market o was validated against.
+func orderAssets(o *models.Order) (string, string) {
+	if o.Audit == nil {
+		return "", ""
+	}
+	base, quote, _ := models.ParseAssets(o.Audit.MarketName)
+	return base, quote
+}
+
+func (s *OrderService) GetBalances(userIdString string) ([]ledger.Balance, error) {
+	userId, err := uuid.Parse(userIdString)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user id %q", userIdString)
+	}
+	return s.ledger.Balances(userId), nil
+}
+
+// CreditAccount deposits amount of asset for a user, only admins may call it.
+func (s *OrderService) CreditAccount(userRole pkg.UserRole, userIdString, asset string, amount float64, reference string) (ledger.Balance, error) {
+	userId, err := s.checkAccountRequest(userRole, userIdString, asset)
+	if err != nil {
+		return ledger.Balance{}, err
+	}
+	balance, err := s.ledger.Credit(userId, asset, amount, reference)
+	if err != nil {
+		return ledger.Balance{}, ledgerError(err)
+	}
+	s.logger.Info("account credited",
+		slog.String("user_id", userIdString),
+		slog.String("asset", balance.Asset),
+		slog.Float64("amount", amount))
+	return balance, nil
+}
+
+// DebitAccount withdraws amount of asset from the available balance of a user, only admins may call it.
+func (s *OrderService) DebitAccount(userRole pkg.UserRole, userIdString, asset string, amount float64, reference string) (ledger.Balance, error) {
+	userId, err := s.checkAccountRequest(userRole, userIdString, asset)
+	if err != nil {
+		return ledger.Balance{}, err
+	}
+	balance, err := s.ledger.Debit(userId, asset, amount, reference)
+	if err != nil {
+		return ledger.Balance{}, ledgerError(err)
+	}
+	s.logger.Info("account debited",
+		slog.String("user_id", userIdString),
+		slog.String("asset", balance.Asset),
+		slog.Float64("amount", amount))
+	return balance, nil
+}
+
+func (s *OrderService) checkAccountRequest(userRole pkg.UserRole, userIdString, asset string) (uuid.UUID, error) {
+	if err := requireAdmin(userRole, "change account balances"); err != nil {
+		return uuid.Nil, err
+	}
+	userId, err := uuid.Parse(userIdString)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid user id %q", userIdString)
+	}
+	if ledger.NormalizeAsset(asset) == "" {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "asset is required")
+	}
+	return userId, nil
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/ewik2k21/grpcOrderService/internal/orderbook"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+)
+
+// AmendOrder changes price and/or quantity of an open LIMIT_ORDER at expectedVersion.
+// A quantity decrease keeps the queue priority of the order, a price change or a
+// quantity increase sends it to the back of its new level and may match it right away.
+func (s *OrderService) AmendOrder(userRole pkg.UserRole, userIdString, orderIdString string, price, quantity *float64, expectedVersion int64) (*models.Order, error) {
+	userId, err := uuid.Parse(userIdString)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user id %q", userIdString)
+	}
+	orderId, err := uuid.Parse(orderIdString)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order id %q", orderIdString)
+	}
+	if price == nil && quantity == nil {
+		return nil, status.Error(codes.InvalidArgument, "price or quantity is required")
+	}
+	if expectedVersion <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "expected_version is required")
+	}
+
+	current, err := s.repo.GetOrder(userId, orderId)
+	if err != nil {
+		return nil, repoError(err)
+	}
+	if current.OrderType != order.OrderType_LIMIT_ORDER {
+		return nil, status.Errorf(codes.FailedPrecondition, "only LIMIT_ORDER can be amended, got %s", current.OrderType.String())
+	}
+	if _, err = CheckMarkets(s.catalog, s.marketStates, userRole, current.MarketId.String()); err != nil {
+		return nil, err
+	}
+
+	amended := *current
+	if price != nil {
+		amended.Price = *price
+	}
+	if quantity != nil {
+		amended.Quantity = *quantity
+	}
+	if amended.Price <= 0 || amended.Quantity <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "price and quantity must be positive")
+	}
+	if amended.IsFilled() {
+		return nil, status.Errorf(codes.InvalidArgument, "quantity must exceed the filled quantity %v", amended.FilledQuantity)
+	}
+	if err = s.checkRisk(userRole, &amended, -1); err != nil {
+		return nil, err
+	}
+
+	keepsPriority := amended.Price == current.Price && amended.Quantity <= current.Quantity
+	var updated *models.Order
+	s.books.Apply(current.MarketId, func(book *orderbook.Book) []orderbook.LevelUpdate {
+		updated, err = s.repo.AmendOrder(userId, orderId, expectedVersion, amended.Price, amended.Quantity,
+			func(o models.Order) error {
+				return s.resizeReservation(&o)
+			})
+		if err != nil {
+			return nil
+		}
+
+		var update orderbook.LevelUpdate
+		var ok bool
+		if keepsPriority {
+			update, ok = book.Reduce(orderId, current.Quantity-amended.Quantity)
+		} else {
+			//matched again below, it rests at the back of its level
+			update, ok = book.Remove(orderId)
+		}
+		if !ok {
+			return nil
+		}
+		return []orderbook.LevelUpdate{update}
+	})
+	if err != nil {
+		return nil, repoError(err)
+	}
+
+	s.logger.Info("order amended",
+		slog.String("order_id", orderIdString),
+		slog.Float64("price", updated.Price),
+		slog.Float64("quantity", updated.Quantity),
+		slog.Bool("kept_priority", keepsPriority))
+	s.publishUpdate(updated)
+	if keepsPriority {
+		return updated, nil
+	}
+	return s.match(updated), nil
+}
+
+// resizeReservation fits the reservation of an open order to what it can still spend.
+func (s *OrderService) resizeReservation(o *models.Order) error {
+	amount := o.Remaining()
+	if o.Side == order.Side_BUY {
+		amount *= o.Price
+	}
+	if err := s.ledger.Resize(o.ID, amount); err != nil {
+		s.logger.Warn("failed resize reservation",
+			slog.String("order_id", o.ID.String()),
+			slog.String("error", err.Error()))
+		return ledgerError(err)
+	}
+	return nil
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"github.com/ewik2k21/grpcOrderService/internal/mappers"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/ewik2k21/grpcOrderService/internal/repositories"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+)
+
+// BatchResult is the outcome of one item of a batch request.
+type BatchResult struct {
+	Order *models.Order
+	Err   error
+}
+
+var errBatchAborted = status.Error(codes.Aborted, "batch aborted because another item failed")
+
+// CreateOrders places a batch of orders of one user, validating every market once
+// against a single catalog snapshot and inserting the batch under one repository lock.
+func (s *OrderService) CreateOrders(ctx context.Context, userRole pkg.UserRole, userId string, requests []*order.CreateOrderRequest, mode order.BatchMode) ([]BatchResult, error) {
+	if err := s.checkBatchSize(len(requests)); err != nil {
+		return nil, err
+	}
+
+	snapshot, err := catalogSnapshot(s.catalog, userRole)
+	if err != nil {
+		return nil, err
+	}
+
+	type marketCheck struct {
+		market *models.Market
+		err    error
+	}
+
+	results := make([]BatchResult, len(requests))
+	checks := make(map[string]marketCheck)
+	failed := false
+	accepted := 0
+
+	for i, request := range requests {
+		request.UserRole = userRole
+		request.UserId = userId
+
+		check, ok := checks[request.GetMarketId()]
+		if !ok {
+			check.market, check.err = checkMarket(snapshot, s.marketStates, request.GetMarketId())
+			checks[request.GetMarketId()] = check
+		}
+		if check.err != nil {
+			results[i].Err = check.err
+			failed = true
+			continue
+		}
+		market := check.market
+
+		if request.GetIdempotencyKey() != "" {
+			results[i].Err = status.Error(codes.InvalidArgument, "idempotency_key is not supported on batch items")
+			failed = true
+			continue
+		}
+
+		mapOrder, err := mappers.MapProtoToOrder(request)
+		if err != nil {
+			results[i].Err = status.Errorf(codes.InvalidArgument, "invalid order: %s", err.Error())
+			failed = true
+			continue
+		}
+		mapOrder.Audit = newOrderAudit(market, s.clock.Now())
+		if err = s.validateOrder(mapOrder); err != nil {
+			results[i].Err = err
+			failed = true
+			continue
+		}
+		if err = s.checkRisk(userRole, mapOrder, accepted); err != nil {
+			results[i].Err = err
+			failed = true
+			continue
+		}
+		if err = s.reserveFunds(market, mapOrder); err != nil {
+			results[i].Err = err
+			failed = true
+			continue
+		}
+		results[i].Order = mapOrder
+		accepted++
+	}
+
+	allOrNothing := mode == order.BatchMode_ALL_OR_NOTHING
+	if failed && allOrNothing {
+		for i := range results {
+			if results[i].Order != nil {
+				s.ledger.Release(results[i].Order.ID)
+			}
+		}
+		return abortBatch(results), nil
+	}
+
+	valid := make([]*models.Order, 0, len(results))
+	positions := make([]int, 0, len(results))
+	for i := range results {
+		if results[i].Err == nil {
+			valid = append(valid, results[i].Order)
+			positions = append(positions, i)
+		}
+	}
+
+	for j, err := range s.repo.CreateOrders(valid, allOrNothing, s.risk.Limits(userRole).MaxOpenOrders) {
+		if err != nil {
+			s.ledger.Release(valid[j].ID)
+			results[positions[j]].Err = repoError(err)
+			results[positions[j]].Order = nil
+			continue
+		}
+		results[positions[j]].Order = s.afterCreate(valid[j])
+	}
+
+	s.logger.Info("order batch processed",
+		slog.String("user_id", userId),
+		slog.Int("size", len(requests)),
+		slog.String("mode", mode.String()))
+	return results, nil
+}
+
+// CancelOrders cancels a batch of orders of one user under one repository lock.
+func (s *OrderService) CancelOrders(ctx context.Context, userIdString string, requests []*order.CancelOrderRequest, mode order.BatchMode) ([]BatchResult, error) {
+	if err := s.checkBatchSize(len(requests)); err != nil {
+		return nil, err
+	}
+
+	userId, err := uuid.Parse(userIdString)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user id %q", userIdString)
+	}
+
+	results := make([]BatchResult, len(requests))
+	targets := make([]repositories.CancelTarget, 0, len(requests))
+	positions := make([]int, 0, len(requests))
+	failed := false
+
+	for i, request := range requests {
+		target, err := cancelTarget(request.GetOrderId(), request.GetClientOrderId(), request.GetExpectedVersion())
+		if err != nil {
+			results[i].Err = err
+			failed = true
+			continue
+		}
+		targets = append(targets, target)
+		positions = append(positions, i)
+	}
+
+	allOrNothing := mode == order.BatchMode_ALL_OR_NOTHING
+	if failed && allOrNothing {
+		return abortBatch(results), nil
+	}
+
+	//client order ids and market modes are checked under the repository lock
+	cancelled, errs := s.repo.CancelOrders(userId, targets, allOrNothing, s.allowCancel)
+	for j := range targets {
+		if errs[j] != nil {
+			results[positions[j]].Err = repoError(errs[j])
+			continue
+		}
+		s.onClosed(cancelled[j])
+		results[positions[j]].Order = cancelled[j]
+	}
+	return results, nil
+}
+
+// cancelTarget parses an order addressed either by order id or, when that is empty,
+// by client order id.
+func cancelTarget(orderIdString, clientOrderId string, expectedVersion int64) (repositories.CancelTarget, error) {
+	target := repositories.CancelTarget{
+		ClientOrderId:   clientOrderId,
+		ExpectedVersion: expectedVersion,
+	}
+	if orderIdString == "" {
+		if target.ClientOrderId == "" {
+			return target, status.Error(codes.InvalidArgument, "order_id or client_order_id is required")
+		}
+		return target, nil
+	}
+
+	orderId, err := uuid.Parse(orderIdString)
+	if err != nil {
+		return target, status.Errorf(codes.InvalidArgument, "invalid order id %q", orderIdString)
+	}
+	target.OrderId = orderId
+	return target, nil
+}
+
+func (s *OrderService) checkBatchSize(size int) error {
+	if size == 0 {
+		return status.Error(codes.InvalidArgument, "batch is empty")
+	}
+	if size > s.maxBatchSize {
+		return status.Errorf(codes.InvalidArgument, "batch of %d items exceeds the limit of %d", size, s.maxBatchSize)
+	}
+	return nil
+}
+
+func abortBatch(results []BatchResult) []BatchResult {
+	for i := range results {
+		results[i].Order = nil
+		if results[i].Err == nil {
+			results[i].Err = errBatchAborted
+		}
+	}
+	return results
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"github.com/ewik2k21/grpcOrderService/internal/orderbook"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *OrderService) GetOrderBook(marketIdString string, depth int) (orderbook.Snapshot, error) {
+	marketId, err := s.bookMarket(marketIdString)
+	if err != nil {
+		return orderbook.Snapshot{}, err
+	}
+	if depth < 0 {
+		return orderbook.Snapshot{}, status.Errorf(codes.InvalidArgument, "depth must not be negative, got %d", depth)
+	}
+	return s.books.Snapshot(marketId, depth), nil
+}
+
+// StreamOrderBook sends a full snapshot of the book of marketIdString followed by every
+// level diff, until ctx is done or a send fails.
+func (s *OrderService) StreamOrderBook(
+	ctx context.Context,
+	marketIdString string,
+	sendSnapshot func(orderbook.Snapshot) error,
+	sendDiff func(orderbook.Diff) error,
+) error {
+	marketId, err := s.bookMarket(marketIdString)
+	if err != nil {
+		return err
+	}
+
+	snapshot, diffs, unsubscribe := s.books.Subscribe(marketId)
+	defer unsubscribe()
+
+	if err = sendSnapshot(snapshot); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case diff, ok := <-diffs:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "order book stream fell behind, resubscribe")
+			}
+			if err = sendDiff(diff); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// bookMarket parses the market of an order book request, only markets in the catalog
+// have a book so unknown ids never reach the book manager.
+func (s *OrderService) bookMarket(marketIdString string) (uuid.UUID, error) {
+	marketId, err := uuid.Parse(marketIdString)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid market id %q", marketIdString)
+	}
+	if !s.catalog.HasMarket(marketId) {
+		return uuid.Nil, status.Error(codes.NotFound, "needed market not found")
+	}
+	return marketId, nil
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/ewik2k21/grpcOrderService/internal/repositories"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+	"time"
+)
+
+// MassCancel atomically cancels every open order matching the filter and publishes
+// one update per cancelled order. Only admins may cancel across users, and only
+// admins cancel in halted markets.
+func (s *OrderService) MassCancel(userRole pkg.UserRole, userIdString, marketIdString string, side *order.Side, orderStatus *order.Status) ([]*models.Order, error) {
+	if userIdString == "" && marketIdString == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id or market_id is required")
+	}
+	admin := userRole == pkg.UserRole_USER_ROLE_ADMIN
+	if !admin && userIdString == "" {
+		return nil, status.Error(codes.PermissionDenied, "only admins may cancel the orders of every user")
+	}
+	if orderStatus != nil && models.IsTerminal(*orderStatus) {
+		return nil, status.Errorf(codes.InvalidArgument, "status %s is terminal, nothing to cancel", orderStatus.String())
+	}
+
+	filter := repositories.OrderFilter{Side: side, Status: orderStatus}
+	var err error
+	if userIdString != "" {
+		if filter.UserId, err = uuid.Parse(userIdString); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid user id %q", userIdString)
+		}
+	}
+	if marketIdString != "" {
+		if filter.MarketId, err = uuid.Parse(marketIdString); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid market id %q", marketIdString)
+		}
+	}
+
+	reason := order.StatusReason_CANCELLED_BY_USER
+	if admin {
+		reason = order.StatusReason_CANCELLED_BY_ADMIN
+	}
+	cancelled := s.repo.MassCancel(filter, reason, "mass cancel", func(o *models.Order) bool {
+		return admin || s.marketStates.Get(o.MarketId).AcceptsCancels()
+	})
+	for _, o := range cancelled {
+		s.onClosed(o)
+	}
+
+	s.logger.Warn("mass cancel",
+		slog.String("user_role", userRole.String()),
+		slog.String("user_id", userIdString),
+		slog.String("market_id", marketIdString),
+		slog.Int("cancelled", len(cancelled)))
+	return cancelled, nil
+}
+
+// cancelOpenOrders cancels every open order of userId in markets that accept cancels.
+func (s *OrderService) cancelOpenOrders(userId uuid.UUID, reason order.StatusReason, detail string) []*models.Order {
+	cancelled := s.repo.MassCancel(repositories.OrderFilter{UserId: userId}, reason, detail, func(o *models.Order) bool {
+		return s.marketStates.Get(o.MarketId).AcceptsCancels()
+	})
+	for _, o := range cancelled {
+		s.onClosed(o)
+	}
+	return cancelled
+}
+
+// CloseOrderUpdatesSession ends a cancel on disconnect session gracefully, the
+// orders of the user stay open when its stream ends.
+func (s *OrderService) CloseOrderUpdatesSession(userIdString, sessionIdString string) error {
+	userId, err := uuid.Parse(userIdString)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid user id %q", userIdString)
+	}
+	sessionId, err := uuid.Parse(sessionIdString)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid session id %q", sessionIdString)
+	}
+	if err = s.disconnects.Close(userId, sessionId); err != nil {
+		return status.Errorf(codes.NotFound, "session %s of user %s not found", sessionIdString, userIdString)
+	}
+	return nil
+}
+
+// CancelAllAfter arms the dead man's switch of a user: unless it is called again
+// within timeout every open order of the user is cancelled. A zero timeout disarms
+// it, the returned deadline is then zero.
+func (s *OrderService) CancelAllAfter(ctx context.Context, userIdString string, timeout time.Duration) (time.Time, error) {
+	userId, err := uuid.Parse(userIdString)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "invalid user id %q", userIdString)
+	}
+	if timeout < 0 || timeout > s.deadMan.policy.MaxTimeout {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "timeout must be between 0 and %s, got %s", s.deadMan.policy.MaxTimeout, timeout)
+	}
+
+	deadline, err := s.deadMan.Arm(ctx, userId, timeout)
+	if err != nil {
+		return time.Time{}, status.Error(codes.Unavailable, "failed to store cancel all after deadline")
+	}
+	return deadline, nil
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"log/slog"
+)
+
+func (s *OrderService) createOrderIdempotent(ctx context.Context, userRole pkg.UserRole, request *order.CreateOrderRequest, idempotencyKey string) (*models.Order, error) {
+	userId, err := uuid.Parse(request.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user id %q", request.GetUserId())
+	}
+
+	hash, err := requestHash(userRole, request)
+	if err != nil {
+		return nil, err
+	}
+
+	record, reserved, err := s.idempotencyRepo.Reserve(ctx, userId, idempotencyKey, hash, s.idempotencyPendingTTL)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, "idempotency store unavailable")
+	}
+	if !reserved {
+		switch {
+		case record.RequestHash != hash:
+			return nil, status.Errorf(codes.AlreadyExists, "idempotency key %q was already used with a different request", idempotencyKey)
+		case record.Pending:
+			return nil, status.Errorf(codes.Aborted, "request with idempotency key %q is still in progress", idempotencyKey)
+		}
+		s.logger.Info("replayed idempotent create order",
+			slog.String("idempotency_key", idempotencyKey),
+			slog.String("order_id", record.OrderId))
+		orderId, err := uuid.Parse(record.OrderId)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "idempotency record holds invalid order id %q", record.OrderId)
+		}
+		return &models.Order{
+			ID:           orderId,
+			Status:       record.Status,
+			StatusReason: record.StatusReason,
+			StatusDetail: record.StatusDetail,
+			Version:      record.Version,
+			CreatedAt:    record.CreatedAt,
+			UpdatedAt:    record.UpdatedAt,
+			TerminalAt:   record.TerminalAt,
+		}, nil
+	}
+
+	created, err := s.createOrder(ctx, userRole, request)
+	if err != nil {
+		s.idempotencyRepo.Release(context.WithoutCancel(ctx), userId, idempotencyKey)
+		return nil, err
+	}
+
+	record.OrderId = created.ID.String()
+	record.Status = created.Status
+	record.StatusReason = created.StatusReason
+	record.StatusDetail = created.StatusDetail
+	record.Version = created.Version
+	record.CreatedAt = created.CreatedAt
+	record.UpdatedAt = created.UpdatedAt
+	record.TerminalAt = created.TerminalAt
+	if err = s.idempotencyRepo.Complete(context.WithoutCancel(ctx), userId, idempotencyKey, record, s.idempotencyWindow); err != nil {
+		//the order exists, a failed bookkeeping write must not turn it into an error
+		s.logger.Error("order created but idempotency record not stored",
+			slog.String("idempotency_key", idempotencyKey),
+			slog.String("order_id", record.OrderId))
+	}
+	return created, nil
+}
+
+// requestHash fingerprints everything in the request except the idempotency key itself.
+func requestHash(userRole pkg.UserRole, request *order.CreateOrderRequest) (string, error) {
+	clone := proto.Clone(request).(*order.CreateOrderRequest)
+	clone.IdempotencyKey = ""
+	clone.UserRole = userRole
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(clone)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func requireAdmin(userRole pkg.UserRole, action string) error {
+	if userRole != pkg.UserRole_USER_ROLE_ADMIN {
+		return status.Errorf(codes.PermissionDenied, "only admins may %s", action)
+	}
+	return nil
+}
+
+func marketModeError(state models.MarketState) error {
+	if state.Mode == order.MarketMode_HALTED {
+		return failedPrecondition(ReasonMarketHalted, "market %s is halted: %s", state.MarketId, state.Reason)
+	}
+	return failedPrecondition(ReasonCancelOnly, "market %s only accepts cancels: %s", state.MarketId, state.Reason)
+}
+
+// allowCancel rejects cancels of orders in markets that do not accept them.
+func (s *OrderService) allowCancel(o *models.Order) error {
+	if state := s.marketStates.Get(o.MarketId); !state.AcceptsCancels() {
+		return marketModeError(state)
+	}
+	return nil
+}
+
+func (s *OrderService) HaltMarket(ctx context.Context, userRole pkg.UserRole, marketIdString, reason string) (models.MarketState, error) {
+	return s.setMarketMode(ctx, userRole, marketIdString, order.MarketMode_HALTED, reason)
+}
+
+func (s *OrderService) ResumeMarket(ctx context.Context, userRole pkg.UserRole, marketIdString, reason string) (models.MarketState, error) {
+	return s.setMarketMode(ctx, userRole, marketIdString, order.MarketMode_TRADING, reason)
+}
+
+func (s *OrderService) SetMarketCancelOnly(ctx context.Context, userRole pkg.UserRole, marketIdString, reason string) (models.MarketState, error) {
+	return s.setMarketMode(ctx, userRole, marketIdString, order.MarketMode_CANCEL_ONLY, reason)
+}
+
+func (s *OrderService) setMarketMode(ctx context.Context, userRole pkg.UserRole, marketIdString string, mode order.MarketMode, reason string) (models.MarketState, error) {
+	if err := requireAdmin(userRole, "change market modes"); err != nil {
+		return models.MarketState{}, err
+	}
+	marketId, err := uuid.Parse(marketIdString)
+	if err != nil {
+		return models.MarketState{}, status.Errorf(codes.InvalidArgument, "invalid market id %q", marketIdString)
+	}
+
+	state, err := s.marketStates.Set(ctx, marketId, mode, reason)
+	if err != nil {
+		return models.MarketState{}, status.Error(codes.Unavailable, "market state store unavailable")
+	}
+	return state, nil
+}
+
+// StreamMarketStates sends the states of markets that are not trading, only of
+// marketIdString when it is set, followed by every change until ctx is done or a send fails.
+func (s *OrderService) StreamMarketStates(ctx context.Context, marketIdString string, send func(models.MarketState) error) error {
+	var marketId uuid.UUID
+	if marketIdString != "" {
+		var err error
+		if marketId, err = uuid.Parse(marketIdString); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid market id %q", marketIdString)
+		}
+	}
+
+	current, updates, unsubscribe := s.marketStates.Subscribe(marketId)
+	defer unsubscribe()
+
+	for _, state := range current {
+		if err := send(state); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case update, ok := <-updates:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "market state stream fell behind, resubscribe")
+			}
+			if err := send(update); err != nil {
+				return err
+			}
+		}
+	}
+}
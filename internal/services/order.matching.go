@@ -0,0 +1,176 @@
+package services
+
+import (
+	"errors"
+	"github.com/ewik2k21/grpcOrderService/internal/marketdata"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/ewik2k21/grpcOrderService/internal/orderbook"
+	"github.com/ewik2k21/grpcOrderService/internal/repositories"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"github.com/google/uuid"
+	"log/slog"
+	"time"
+)
+
+// match executes a released order against the opposite side of its book at the maker
+// prices. What is left rests in the book when the order allows it, otherwise it expires.
+// It returns the order state to report.
+func (s *OrderService) match(taker *models.Order) *models.Order {
+	makerSide := opposite(taker.Side)
+	accept := func(price float64) bool {
+		return acceptsPrice(taker, price)
+	}
+	base, quote := orderAssets(taker)
+
+	var trades []*models.Trade
+	var makers []*models.Order
+	var settleErr error
+	live, rested := true, false
+	s.books.Apply(taker.MarketId, func(book *orderbook.Book) []orderbook.LevelUpdate {
+		updates := make([]orderbook.LevelUpdate, 0)
+		if taker.TimeInForce == order.TimeInForce_FOK &&
+			book.Liquidity(makerSide, accept) < taker.Remaining()-models.QuantityEpsilon {
+			return nil
+		}
+
+		for !taker.IsFilled() {
+			maker, ok := book.Best(makerSide)
+			if !ok || !accept(maker.Price) {
+				break
+			}
+
+			trade := newTrade(taker, maker, min(taker.Remaining(), maker.Remaining), s.clock.Now())
+			takerState, makerState, err := s.repo.ExecuteTrade(trade, func(takerOrder, makerOrder models.Order) error {
+				buyer, seller := takerOrder, makerOrder
+				if taker.Side == order.Side_SELL {
+					buyer, seller = makerOrder, takerOrder
+				}
+				s.fees.Quote(trade, buyer.UserRole, seller.UserRole, base, quote)
+				return s.ledger.Settle(trade, base, quote)
+			})
+			if errors.Is(err, repositories.ErrMakerNotFillable) {
+				//closed while resting, its own removal is waiting for this book
+				update, _ := book.Remove(maker.OrderId)
+				updates = append(updates, update)
+				continue
+			}
+			if errors.Is(err, repositories.ErrTakerNotFillable) {
+				//the taker was cancelled while matching
+				live = false
+				break
+			}
+			if err != nil {
+				//a market buy priced above its reservation ran out of funds
+				s.logger.Warn("trade not settled",
+					slog.String("order_id", taker.ID.String()),
+					slog.String("error", err.Error()))
+				settleErr = err
+				break
+			}
+
+			update, _ := book.Reduce(maker.OrderId, trade.Quantity)
+			updates = append(updates, update)
+			s.fees.Record(trade)
+			s.trades.AddTrade(trade)
+			s.positions.Apply(trade)
+			trades = append(trades, trade)
+			makers = append(makers, makerState)
+			taker = takerState
+		}
+
+		if live && !taker.IsFilled() && restsInBook(taker) {
+			//a cancel that ran before this book was locked found nothing to remove
+			current, err := s.repo.GetOrder(taker.UserId, taker.ID)
+			live = err == nil && !models.IsTerminal(current.Status)
+			if live {
+				updates = append(updates, book.Add(&orderbook.Resting{
+					OrderId:   taker.ID,
+					UserId:    taker.UserId,
+					Side:      taker.Side,
+					Price:     taker.Price,
+					Remaining: taker.Remaining(),
+				}))
+				rested = true
+			}
+		}
+		return updates
+	})
+
+	for _, maker := range makers {
+		s.publishUpdate(maker)
+	}
+	if len(trades) > 0 {
+		s.publishUpdate(taker)
+		last := trades[len(trades)-1]
+		s.logger.Info("order matched",
+			slog.String("order_id", taker.ID.String()),
+			slog.Int("trades", len(trades)),
+			slog.Float64("filled_quantity", taker.FilledQuantity))
+		s.onPrice(taker.MarketId, marketdata.Price{
+			Value:     last.Price,
+			Source:    marketdata.SourceLastTrade,
+			UpdatedAt: last.ExecutedAt,
+		})
+	}
+
+	if live && !rested && !taker.IsFilled() {
+		//market, IOC and FOK orders never wait for liquidity
+		reason, detail := order.StatusReason_UNFILLED_REMAINDER_EXPIRED, ""
+		switch {
+		case settleErr != nil:
+			detail = settleErr.Error()
+		case taker.TimeInForce == order.TimeInForce_FOK:
+			reason = order.StatusReason_FOK_NOT_FILLABLE
+		}
+		if expired := s.expireOrder(taker.ID, reason, detail); expired != nil {
+			return expired
+		}
+	}
+	return taker
+}
+
+// restsInBook reports whether an order waits in the order book for a counterparty.
+func restsInBook(o *models.Order) bool {
+	if o.OrderType != order.OrderType_LIMIT_ORDER && o.OrderType != order.OrderType_STOP_LIMIT {
+		return false
+	}
+	return o.TimeInForce == order.TimeInForce_GTC || o.TimeInForce == order.TimeInForce_GTD
+}
+
+// acceptsPrice reports whether taker can trade at price, market orders take any price.
+func acceptsPrice(taker *models.Order, price float64) bool {
+	switch {
+	case taker.OrderType == order.OrderType_MARKET_ORDER || taker.OrderType == order.OrderType_STOP_MARKET:
+		return true
+	case taker.Side == order.Side_BUY:
+		return price <= taker.Price
+	default:
+		return price >= taker.Price
+	}
+}
+
+func opposite(side order.Side) order.Side {
+	if side == order.Side_BUY {
+		return order.Side_SELL
+	}
+	return order.Side_BUY
+}
+
+func newTrade(taker *models.Order, maker *orderbook.Resting, quantity float64, at time.Time) *models.Trade {
+	trade := &models.Trade{
+		ID:         uuid.New(),
+		MarketId:   taker.MarketId,
+		Price:      maker.Price,
+		Quantity:   quantity,
+		ExecutedAt: at,
+		TakerSide:  taker.Side,
+	}
+	if taker.Side == order.Side_BUY {
+		trade.BuyOrderId, trade.BuyUserId = taker.ID, taker.UserId
+		trade.SellOrderId, trade.SellUserId = maker.OrderId, maker.UserId
+	} else {
+		trade.SellOrderId, trade.SellUserId = taker.ID, taker.UserId
+		trade.BuyOrderId, trade.BuyUserId = maker.OrderId, maker.UserId
+	}
+	return trade
+}
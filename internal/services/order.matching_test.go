@@ -0,0 +1,191 @@
+package services
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/clock"
+	"github.com/ewik2k21/grpcOrderService/internal/events"
+	"github.com/ewik2k21/grpcOrderService/internal/fees"
+	"github.com/ewik2k21/grpcOrderService/internal/idgen"
+	"github.com/ewik2k21/grpcOrderService/internal/ledger"
+	"github.com/ewik2k21/grpcOrderService/internal/marketdata"
+	"github.com/ewik2k21/grpcOrderService/internal/marketstate"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/ewik2k21/grpcOrderService/internal/orderbook"
+	"github.com/ewik2k21/grpcOrderService/internal/positions"
+	"github.com/ewik2k21/grpcOrderService/internal/repositories"
+	"github.com/ewik2k21/grpcOrderService/internal/risk"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"github.com/google/uuid"
+	"io"
+	"log/slog"
+	"math"
+	"testing"
+	"time"
+)
+
+var testMarket = &models.Market{ID: uuid.New(), Name: "BTC/USDT"}
+
+func newTestService(t *testing.T) *OrderService {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	clk := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	return NewOrderService(OrderServiceDeps{
+		Repo:         repositories.NewOrderRepository(idgen.TimeOrdered{}, clk, logger),
+		Trades:       repositories.NewTradeRepository(logger),
+		MarketStates: marketstate.NewStore(nil, logger, 16),
+		Broker:       events.NewOrderBroker(logger, 16),
+		Prices:       marketdata.NewPriceStore(),
+		Books:        orderbook.NewManager(logger, 16),
+		Risk:         risk.NewEngine(risk.Limits{}, nil),
+		Ledger:       ledger.NewLedger(logger),
+		Positions:    positions.NewTracker(logger, 16),
+		Fees:         fees.NewEngine(fees.Schedule{}, nil),
+		Clock:        clk,
+		Logger:       logger,
+	}, OrderServicePolicy{IdempotencyWindow: time.Hour, MaxBatchSize: 100})
+}
+
+// placeOrder funds, stores and matches an order the way CreateOrder does past validation.
+func placeOrder(t *testing.T, s *OrderService, o *models.Order) *models.Order {
+	t.Helper()
+	o.MarketId = testMarket.ID
+	o.Audit = newOrderAudit(testMarket, s.clock.Now())
+	asset, amount, err := s.reservation(testMarket, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = s.ledger.Credit(o.UserId, asset, amount, "deposit"); err != nil {
+		t.Fatal(err)
+	}
+	if err = s.reserveFunds(testMarket, o); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = s.repo.CreateOrder(o, 0); err != nil {
+		t.Fatal(err)
+	}
+	return s.afterCreate(o)
+}
+
+func TestMatch(t *testing.T) {
+	type resting struct {
+		side     order.Side
+		price    float64
+		quantity float64
+	}
+	tests := []struct {
+		name        string
+		makers      []resting
+		taker       models.Order
+		wantStatus  order.Status
+		wantReason  order.StatusReason
+		wantFilled  float64
+		wantAvg     float64
+		wantMakers  []order.Status
+		wantResting int
+	}{
+		{
+			name:       "limit buy fills a resting sell",
+			makers:     []resting{{order.Side_SELL, 100, 1}},
+			taker:      models.Order{OrderType: order.OrderType_LIMIT_ORDER, Side: order.Side_BUY, Price: 100, Quantity: 1},
+			wantStatus: order.Status_PROCESSED, wantReason: order.StatusReason_FILLED,
+			wantFilled: 1, wantAvg: 100,
+			wantMakers: []order.Status{order.Status_PROCESSED},
+		},
+		{
+			name:       "trades at the maker price",
+			makers:     []resting{{order.Side_SELL, 95, 1}},
+			taker:      models.Order{OrderType: order.OrderType_LIMIT_ORDER, Side: order.Side_BUY, Price: 100, Quantity: 1},
+			wantStatus: order.Status_PROCESSED, wantReason: order.StatusReason_FILLED,
+			wantFilled: 1, wantAvg: 95,
+			wantMakers: []order.Status{order.Status_PROCESSED},
+		},
+		{
+			name:       "remainder rests in the book",
+			makers:     []resting{{order.Side_SELL, 100, 1}},
+			taker:      models.Order{OrderType: order.OrderType_LIMIT_ORDER, Side: order.Side_BUY, Price: 100, Quantity: 3},
+			wantStatus: order.Status_PROCESSING, wantReason: order.StatusReason_PARTIALLY_FILLED,
+			wantFilled: 1, wantAvg: 100,
+			wantMakers:  []order.Status{order.Status_PROCESSED},
+			wantResting: 1,
+		},
+		{
+			name:        "no cross rests untouched",
+			makers:      []resting{{order.Side_SELL, 101, 1}},
+			taker:       models.Order{OrderType: order.OrderType_LIMIT_ORDER, Side: order.Side_BUY, Price: 100, Quantity: 1},
+			wantStatus:  order.Status_CREATED,
+			wantMakers:  []order.Status{order.Status_CREATED},
+			wantResting: 2,
+		},
+		{
+			name:       "best price first across levels",
+			makers:     []resting{{order.Side_BUY, 90, 1}, {order.Side_BUY, 100, 1}},
+			taker:      models.Order{OrderType: order.OrderType_MARKET_ORDER, Side: order.Side_SELL, Quantity: 2},
+			wantStatus: order.Status_PROCESSED, wantReason: order.StatusReason_FILLED,
+			wantFilled: 2, wantAvg: 95,
+			wantMakers: []order.Status{order.Status_PROCESSED, order.Status_PROCESSED},
+		},
+		{
+			name:       "IOC remainder expires",
+			makers:     []resting{{order.Side_SELL, 100, 1}},
+			taker:      models.Order{OrderType: order.OrderType_LIMIT_ORDER, TimeInForce: order.TimeInForce_IOC, Side: order.Side_BUY, Price: 100, Quantity: 2},
+			wantStatus: order.Status_EXPIRED, wantReason: order.StatusReason_UNFILLED_REMAINDER_EXPIRED,
+			wantFilled: 1, wantAvg: 100,
+			wantMakers: []order.Status{order.Status_PROCESSED},
+		},
+		{
+			name:        "FOK without enough liquidity does not trade",
+			makers:      []resting{{order.Side_SELL, 100, 1}},
+			taker:       models.Order{OrderType: order.OrderType_LIMIT_ORDER, TimeInForce: order.TimeInForce_FOK, Side: order.Side_BUY, Price: 100, Quantity: 2},
+			wantStatus:  order.Status_EXPIRED,
+			wantReason:  order.StatusReason_FOK_NOT_FILLABLE,
+			wantMakers:  []order.Status{order.Status_CREATED},
+			wantResting: 1,
+		},
+		{
+			name:       "market order on an empty book expires",
+			taker:      models.Order{OrderType: order.OrderType_MARKET_ORDER, Side: order.Side_SELL, Quantity: 1},
+			wantStatus: order.Status_EXPIRED, wantReason: order.StatusReason_UNFILLED_REMAINDER_EXPIRED,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t)
+			makers := make([]*models.Order, len(tt.makers))
+			for i, m := range tt.makers {
+				makers[i] = placeOrder(t, s, &models.Order{
+					UserId:    uuid.New(),
+					OrderType: order.OrderType_LIMIT_ORDER,
+					Side:      m.side,
+					Price:     m.price,
+					Quantity:  m.quantity,
+				})
+			}
+
+			taker := tt.taker
+			taker.UserId = uuid.New()
+			got := placeOrder(t, s, &taker)
+
+			if got.Status != tt.wantStatus || got.StatusReason != tt.wantReason {
+				t.Errorf("taker %s/%s, want %s/%s", got.Status, got.StatusReason, tt.wantStatus, tt.wantReason)
+			}
+			if math.Abs(got.FilledQuantity-tt.wantFilled) > models.QuantityEpsilon {
+				t.Errorf("filled %v, want %v", got.FilledQuantity, tt.wantFilled)
+			}
+			if math.Abs(got.AvgFillPrice-tt.wantAvg) > models.QuantityEpsilon {
+				t.Errorf("average fill price %v, want %v", got.AvgFillPrice, tt.wantAvg)
+			}
+			for i, maker := range makers {
+				stored, err := s.repo.GetOrder(maker.UserId, maker.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if stored.Status != tt.wantMakers[i] {
+					t.Errorf("maker %d %s, want %s", i, stored.Status, tt.wantMakers[i])
+				}
+			}
+			snapshot := s.books.Snapshot(testMarket.ID, 0)
+			if resting := len(snapshot.Bids) + len(snapshot.Asks); resting != tt.wantResting {
+				t.Errorf("%d price levels rest in the book, want %d", resting, tt.wantResting)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetPositions returns the positions of a user, only in marketIdString when it is set.
+func (s *OrderService) GetPositions(userIdString, marketIdString string) ([]models.Position, error) {
+	userId, err := uuid.Parse(userIdString)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user id %q", userIdString)
+	}
+
+	var marketId uuid.UUID
+	if marketIdString != "" {
+		if marketId, err = uuid.Parse(marketIdString); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid market id %q", marketIdString)
+		}
+	}
+	return s.positions.Positions(userId, marketId), nil
+}
+
+// StreamPositions sends the current positions of a user followed by every change,
+// until ctx is done or a send fails.
+func (s *OrderService) StreamPositions(ctx context.Context, userIdString string, send func(position models.Position, snapshot bool) error) error {
+	userId, err := uuid.Parse(userIdString)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid user id %q", userIdString)
+	}
+
+	current, updates, unsubscribe := s.positions.Subscribe(userId)
+	defer unsubscribe()
+
+	for _, position := range current {
+		if err = send(position, true); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case update, ok := <-updates:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "position stream fell behind, resubscribe")
+			}
+			if err = send(update, false); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/ewik2k21/grpcOrderService/internal/risk"
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"log/slog"
+)
+
+// checkRisk runs the pre-trade risk chain on an order about to be stored. pending
+// counts the orders of the same user accepted earlier in a batch and not stored yet,
+// -1 leaves out an order that is already stored.
+func (s *OrderService) checkRisk(userRole pkg.UserRole, o *models.Order, pending int) error {
+	if rejection := s.risk.Evaluate(s.riskInput(userRole, o, pending)); rejection != nil {
+		s.logger.Warn("order rejected by risk check",
+			slog.String("user_id", o.UserId.String()),
+			slog.String("check", rejection.Check),
+			slog.String("reason", rejection.Reason))
+		return failedPrecondition(rejection.Reason, "%s", rejection.Message)
+	}
+	return nil
+}
+
+// riskViolations runs every risk check on an order that is not placed.
+func (s *OrderService) riskViolations(userRole pkg.UserRole, o *models.Order) []error {
+	var violations []error
+	for _, rejection := range s.risk.EvaluateAll(s.riskInput(userRole, o, 0)) {
+		violations = append(violations, failedPrecondition(rejection.Reason, "%s", rejection.Message))
+	}
+	return violations
+}
+
+func (s *OrderService) riskInput(userRole pkg.UserRole, o *models.Order, pending int) *risk.Input {
+	in := &risk.Input{
+		Role:       userRole,
+		Order:      o,
+		OpenOrders: s.repo.CountOpenOrders(o.UserId) + pending,
+	}
+	if price, ok := s.prices.Reference(o.MarketId); ok {
+		in.ReferencePrice = price.Value
+	}
+	return in
+}
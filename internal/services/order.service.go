@@ -2,155 +2,515 @@ package services
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"github.com/ewik2k21/grpcOrderService/internal/catalog"
+	"github.com/ewik2k21/grpcOrderService/internal/clock"
+	"github.com/ewik2k21/grpcOrderService/internal/events"
+	"github.com/ewik2k21/grpcOrderService/internal/fees"
+	"github.com/ewik2k21/grpcOrderService/internal/ledger"
 	"github.com/ewik2k21/grpcOrderService/internal/mappers"
+	"github.com/ewik2k21/grpcOrderService/internal/marketdata"
+	"github.com/ewik2k21/grpcOrderService/internal/marketstate"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/ewik2k21/grpcOrderService/internal/orderbook"
+	"github.com/ewik2k21/grpcOrderService/internal/positions"
 	"github.com/ewik2k21/grpcOrderService/internal/repositories"
+	"github.com/ewik2k21/grpcOrderService/internal/risk"
+	"github.com/ewik2k21/grpcOrderService/internal/trigger"
 	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
 	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
 	"github.com/google/uuid"
-	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"log/slog"
 	"time"
 )
 
+const (
+	defaultOrdersPageSize = 100
+	maxOrdersPageSize     = 1000
+)
+
 type OrderService struct {
-	repo        *repositories.OrderRepository
-	client      pkg.SpotInstrumentServiceClient
-	logger      *slog.Logger
-	redisClient *redis.Client
-	cacheTTL    time.Duration
-}
-
-//redis todo
-
-func NewOrderService(
-	repo *repositories.OrderRepository,
-	client pkg.SpotInstrumentServiceClient,
-	logger *slog.Logger,
-	redisClient *redis.Client,
-	cacheTTL time.Duration,
-) *OrderService {
-	return &OrderService{
-		repo:        repo,
-		client:      client,
-		logger:      logger,
-		redisClient: redisClient,
-		cacheTTL:    cacheTTL,
-	}
-}
-
-func (s *OrderService) CreateOrder(ctx context.Context, userRole pkg.UserRole, request *order.CreateOrderRequest) (string, *order.Status, error) {
-	cacheKey := fmt.Sprintf("markets:%v", userRole.String())
-
-	cachedData, err := s.redisClient.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var cachedResp pkg.ViewMarketsResponse
-		if err := json.Unmarshal([]byte(cachedData), &cachedResp); err == nil {
-			return CheckMarkets(&cachedResp, request, s)
+	repo                  *repositories.OrderRepository
+	idempotencyRepo       *repositories.IdempotencyRepository
+	trades                *repositories.TradeRepository
+	catalog               *catalog.MarketCatalog
+	marketStates          *marketstate.Store
+	broker                *events.OrderBroker
+	clock                 clock.Clock
+	expiry                *ExpiryScheduler
+	disconnects           *DisconnectGuard
+	deadMan               *DeadManSwitch
+	triggers              *trigger.Book
+	books                 *orderbook.Manager
+	risk                  *risk.Engine
+	ledger                *ledger.Ledger
+	positions             *positions.Tracker
+	fees                  *fees.Engine
+	prices                *marketdata.PriceStore
+	logger                *slog.Logger
+	idempotencyWindow     time.Duration
+	idempotencyPendingTTL time.Duration
+	maxBatchSize          int
+}
+
+// OrderServiceDeps are the collaborators of the order service.
+type OrderServiceDeps struct {
+	Repo         *repositories.OrderRepository
+	Idempotency  *repositories.IdempotencyRepository
+	Trades       *repositories.TradeRepository
+	Deadlines    *repositories.DeadlineRepository
+	Catalog      *catalog.MarketCatalog
+	MarketStates *marketstate.Store
+	Broker       *events.OrderBroker
+	Prices       *marketdata.PriceStore
+	Books        *orderbook.Manager
+	Risk         *risk.Engine
+	Ledger       *ledger.Ledger
+	Positions    *positions.Tracker
+	Fees         *fees.Engine
+	Clock        clock.Clock
+	Logger       *slog.Logger
+}
+
+// OrderServicePolicy holds the settings of the order service.
+type OrderServicePolicy struct {
+	// IdempotencyWindow is how long CreateOrder idempotency keys are remembered
+	IdempotencyWindow time.Duration
+	// IdempotencyPendingTTL is how long a key stays claimed by a request that never finished
+	IdempotencyPendingTTL time.Duration
+	// MaxBatchSize is the most items a CreateOrders or CancelOrders call may carry
+	MaxBatchSize int
+	// CancelOnDisconnectGrace is how long a dropped cancel_on_disconnect session may reconnect
+	// before its open orders are cancelled
+	CancelOnDisconnectGrace time.Duration
+	DeadMan                 DeadManPolicy
+}
+
+func NewOrderService(deps OrderServiceDeps, policy OrderServicePolicy) *OrderService {
+	s := &OrderService{
+		repo:                  deps.Repo,
+		idempotencyRepo:       deps.Idempotency,
+		trades:                deps.Trades,
+		catalog:               deps.Catalog,
+		marketStates:          deps.MarketStates,
+		broker:                deps.Broker,
+		clock:                 deps.Clock,
+		triggers:              trigger.NewBook(),
+		prices:                deps.Prices,
+		books:                 deps.Books,
+		risk:                  deps.Risk,
+		ledger:                deps.Ledger,
+		positions:             deps.Positions,
+		fees:                  deps.Fees,
+		logger:                deps.Logger,
+		idempotencyWindow:     policy.IdempotencyWindow,
+		idempotencyPendingTTL: policy.IdempotencyPendingTTL,
+		maxBatchSize:          policy.MaxBatchSize,
+	}
+	s.expiry = NewExpiryScheduler(deps.Clock, func(orderId uuid.UUID) {
+		s.expireOrder(orderId, order.StatusReason_GTD_EXPIRED, "")
+	})
+	s.disconnects = NewDisconnectGuard(deps.Clock, policy.CancelOnDisconnectGrace, deps.Logger, func(userId uuid.UUID) int {
+		return len(s.cancelOpenOrders(userId, order.StatusReason_CANCELLED_ON_DISCONNECT, "order updates session dropped"))
+	})
+	s.deadMan = NewDeadManSwitch(deps.Deadlines, deps.Clock, policy.DeadMan, deps.Logger, func(userId uuid.UUID) int {
+		return len(s.cancelOpenOrders(userId, order.StatusReason_CANCELLED_BY_DEAD_MAN_SWITCH, "cancel all after deadline ran out"))
+	})
+	return s
+}
+
+// Run starts the background work of the service until ctx is done.
+func (s *OrderService) Run(ctx context.Context) {
+	go s.disconnects.Run(ctx)
+	go s.deadMan.Run(ctx)
+	s.expiry.Run(ctx)
+}
+
+// CreateOrder places an order. With a non empty idempotencyKey a retry of the same
+// request returns the original order instead of creating a new one.
+func (s *OrderService) CreateOrder(ctx context.Context, userRole pkg.UserRole, request *order.CreateOrderRequest, idempotencyKey string) (*models.Order, error) {
+	if idempotencyKey == "" {
+		return s.createOrder(ctx, userRole, request)
+	}
+	return s.createOrderIdempotent(ctx, userRole, request, idempotencyKey)
+}
+
+func (s *OrderService) createOrder(ctx context.Context, userRole pkg.UserRole, request *order.CreateOrderRequest) (*models.Order, error) {
+	market, err := CheckMarkets(s.catalog, s.marketStates, userRole, request.GetMarketId())
+	if err != nil {
+		return nil, err
+	}
+
+	mapOrder, err := mappers.MapProtoToOrder(request)
+	if err != nil {
+		s.logger.Error("failed mapping proto to order", slog.String("error", err.Error()))
+		return nil, err
+	}
+	mapOrder.Audit = newOrderAudit(market, s.clock.Now())
+	if err = s.validateOrder(mapOrder); err != nil {
+		return nil, err
+	}
+	if err = s.checkRisk(userRole, mapOrder, 0); err != nil {
+		return nil, err
+	}
+	if err = s.reserveFunds(market, mapOrder); err != nil {
+		return nil, err
+	}
+
+	if _, _, err = s.repo.CreateOrder(mapOrder, s.risk.Limits(userRole).MaxOpenOrders); err != nil {
+		s.ledger.Release(mapOrder.ID)
+		return nil, repoError(err)
+	}
+	return s.afterCreate(mapOrder), nil
+}
+
+func (s *OrderService) validateOrder(o *models.Order) error {
+	if violations := s.orderViolations(o); len(violations) > 0 {
+		return violations[0]
+	}
+	return nil
+}
+
+// orderViolations returns every trading rule o breaks.
+func (s *OrderService) orderViolations(o *models.Order) []error {
+	var violations []error
+	if o.Quantity <= 0 {
+		violations = append(violations, status.Error(codes.InvalidArgument, "quantity must be positive"))
+	}
+	if o.OrderType == order.OrderType_LIMIT_ORDER && o.Price <= 0 {
+		violations = append(violations, status.Error(codes.InvalidArgument, "LIMIT_ORDER requires a positive price"))
+	}
+	if models.IsStop(o.OrderType) {
+		if o.StopPrice <= 0 {
+			violations = append(violations, status.Errorf(codes.InvalidArgument, "%s requires a positive stop_price", o.OrderType.String()))
+		}
+		if o.OrderType == order.OrderType_STOP_LIMIT && o.Price <= 0 {
+			violations = append(violations, status.Error(codes.InvalidArgument, "STOP_LIMIT requires a positive price"))
+		}
+		if o.TimeInForce == order.TimeInForce_IOC || o.TimeInForce == order.TimeInForce_FOK {
+			violations = append(violations, status.Errorf(codes.InvalidArgument, "%s does not support %s", o.OrderType.String(), o.TimeInForce.String()))
+		}
+	} else if o.StopPrice != 0 {
+		violations = append(violations, status.Errorf(codes.InvalidArgument, "stop_price is not allowed for %s", o.OrderType.String()))
+	}
+
+	switch o.TimeInForce {
+	case order.TimeInForce_GTD:
+		if o.OrderType != order.OrderType_LIMIT_ORDER && o.OrderType != order.OrderType_STOP_LIMIT {
+			violations = append(violations, status.Error(codes.InvalidArgument, "GTD is only allowed for LIMIT_ORDER and STOP_LIMIT"))
 		}
+		if o.ExpireAt == nil || !o.ExpireAt.After(s.clock.Now()) {
+			violations = append(violations, status.Error(codes.InvalidArgument, "GTD requires expire_at in the future"))
+		}
+	default:
+		if o.ExpireAt != nil {
+			violations = append(violations, status.Errorf(codes.InvalidArgument, "expire_at is only allowed with GTD, got %s", o.TimeInForce.String()))
+		}
+	}
+	return violations
+}
+
+// afterCreate publishes a created order and applies its time in force, it returns the order state to report.
+func (s *OrderService) afterCreate(created *models.Order) *models.Order {
+	s.broker.Publish(created)
+
+	if created.TimeInForce == order.TimeInForce_GTD {
+		s.expiry.Schedule(created.ID, *created.ExpireAt)
+	}
+	if created.Status == order.Status_UNTRIGGERED {
+		return s.holdStop(created)
+	}
+	return s.release(created)
+}
+
+// release hands a live order to matching, it returns the order state to report.
+func (s *OrderService) release(created *models.Order) *models.Order {
+	return s.match(created)
+}
+
+// expireOrder moves a live order to EXPIRED for reason and publishes it, nil if it was already terminal.
+func (s *OrderService) expireOrder(orderId uuid.UUID, reason order.StatusReason, detail string) *models.Order {
+	expired, err := s.repo.ExpireOrder(orderId, reason, detail)
+	if err != nil {
+		return nil
+	}
+	s.onClosed(expired)
+	return expired
+}
+
+// publishUpdate publishes a changed order, closing it first when it became terminal.
+func (s *OrderService) publishUpdate(updated *models.Order) {
+	if models.IsTerminal(updated.Status) {
+		s.onClosed(updated)
+		return
+	}
+	s.broker.Publish(updated)
+}
+
+// onClosed publishes an order that reached a terminal status and drops what it still holds.
+func (s *OrderService) onClosed(closed *models.Order) {
+	if models.IsStop(closed.OrderType) && closed.TriggeredAt == nil {
+		s.triggers.Remove(closed.MarketId, closed.ID)
+	}
+	if restsInBook(closed) {
+		s.books.Remove(closed.MarketId, closed.ID)
+	}
+	s.ledger.Release(closed.ID)
+	s.broker.Publish(closed)
+}
+
+// CheckMarkets looks the market up in the synced catalog of userRole and checks that
+// operations did not halt it, it never calls the spot instrument service.
+func CheckMarkets(marketCatalog *catalog.MarketCatalog, marketStates *marketstate.Store, userRole pkg.UserRole, marketIdString string) (*models.Market, error) {
+	snapshot, err := catalogSnapshot(marketCatalog, userRole)
+	if err != nil {
+		return nil, err
+	}
+	return checkMarket(snapshot, marketStates, marketIdString)
+}
+
+func catalogSnapshot(marketCatalog *catalog.MarketCatalog, userRole pkg.UserRole) (*catalog.Snapshot, error) {
+	snapshot, ok := marketCatalog.Snapshot(userRole)
+	if !ok {
+		return nil, status.Errorf(codes.Unavailable, "market catalog for role %s is not synced yet", userRole.String())
 	}
+	return snapshot, nil
+}
 
-	resp, err := s.client.ViewMarkets(
-		ctx,
-		&pkg.ViewMarketsRequest{
-			UserRole: userRole,
-		})
+func checkMarket(snapshot *catalog.Snapshot, marketStates *marketstate.Store, marketIdString string) (*models.Market, error) {
+	marketId, err := uuid.Parse(marketIdString)
 	if err != nil {
-		s.logger.Error("error request view markets from clients", slog.String("error", err.Error()))
-		return "", nil, err
+		return nil, status.Errorf(codes.InvalidArgument, "invalid market id %q", marketIdString)
+	}
+
+	market, ok := snapshot.Markets[marketId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "needed market not found")
+	}
+	if market.DeletedAt != nil {
+		return nil, failedPrecondition(ReasonMarketDeleted, "market %s was deleted at %s",
+			market.ID, market.DeletedAt.Format(time.RFC3339))
+	}
+	if !market.Enabled {
+		return nil, failedPrecondition(ReasonMarketDisabled, "market %s is disabled", market.ID)
+	}
+	if state := marketStates.Get(market.ID); !state.AcceptsOrders() {
+		return nil, marketModeError(state)
+	}
+	return market, nil
+}
+
+func newOrderAudit(market *models.Market, now time.Time) *models.OrderAudit {
+	return &models.OrderAudit{
+		MarketName:      market.Name,
+		MarketEnabled:   market.Enabled,
+		MarketDeletedAt: market.DeletedAt,
+		ValidatedAt:     now,
+	}
+}
+
+// RefreshMarketCatalog forces a catalog sync of roles, or of every role when roles is empty.
+// userRole is the role of the caller.
+func (s *OrderService) RefreshMarketCatalog(ctx context.Context, userRole pkg.UserRole, roles []pkg.UserRole) ([]*catalog.Snapshot, error) {
+	if err := requireAdmin(userRole, "refresh the market catalog"); err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		roles = catalog.Roles()
 	}
 
-	dataBytes, err := json.Marshal(resp)
-	if err == nil {
-		err = s.redisClient.SetEx(ctx, cacheKey, dataBytes, s.cacheTTL).Err()
+	snapshots := make([]*catalog.Snapshot, 0, len(roles))
+	for _, role := range roles {
+		snapshot, err := s.catalog.Sync(ctx, role, true)
 		if err != nil {
-			s.logger.Error("failed to cache data: %v", err)
-		} else {
-			s.logger.Info("data cached")
+			s.logger.Error("failed refresh market catalog",
+				slog.String("role", role.String()),
+				slog.String("error", err.Error()))
+			return nil, err
 		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, nil
+}
+
+// GetOrderStatus returns the order for its status fields, the handler only exposes those.
+func (s *OrderService) GetOrderStatus(userIdString, orderIdString, clientOrderId string) (*models.Order, error) {
+	userId, orderId, err := s.resolveOrderId(userIdString, orderIdString, clientOrderId)
+	if err != nil {
+		return nil, err
+	}
+
+	neededOrder, err := s.repo.GetOrder(userId, orderId)
+	if err != nil {
+		s.logger.Error("error get order status from repo", slog.String("error", err.Error()))
+		return nil, repoError(err)
 	}
 
-	return CheckMarkets(resp, request, s)
+	return neededOrder, nil
 
 }
 
-func CheckMarkets(resp *pkg.ViewMarketsResponse, request *order.CreateOrderRequest, s *OrderService) (string, *order.Status, error) {
-	markets, err := mappers.MapProtoToMarkets(resp)
+func (s *OrderService) GetOrder(userIdString, orderIdString, clientOrderId string) (*models.Order, error) {
+	userId, orderId, err := s.resolveOrderId(userIdString, orderIdString, clientOrderId)
 	if err != nil {
-		s.logger.Error("failed mapping proto to markets", slog.String("error", err.Error()))
-		return "", nil, err
+		return nil, err
 	}
 
-	marketId := request.GetMarketId()
-	var ok = false
+	neededOrder, err := s.repo.GetOrder(userId, orderId)
+	if err != nil {
+		return nil, repoError(err)
+	}
+	return neededOrder, nil
+}
 
-	mapOrder, err := mappers.MapProtoToOrder(request)
+// ListOrders pages through the orders of a user, optionally of one market, side or
+// status, oldest first. The page token is the last id of the previous page and is
+// empty on the last page.
+func (s *OrderService) ListOrders(userIdString, marketIdString string, side *order.Side, orderStatus *order.Status, pageSize int, pageToken string) ([]*models.Order, string, error) {
+	userId, err := uuid.Parse(userIdString)
 	if err != nil {
-		s.logger.Error("failed mapping proto to order", slog.String("error", err.Error()))
-		return "", nil, err
+		return nil, "", status.Errorf(codes.InvalidArgument, "invalid user id %q", userIdString)
+	}
+	filter := repositories.OrderFilter{Side: side, Status: orderStatus}
+	if marketIdString != "" {
+		if filter.MarketId, err = uuid.Parse(marketIdString); err != nil {
+			return nil, "", status.Errorf(codes.InvalidArgument, "invalid market id %q", marketIdString)
+		}
 	}
 
-	var orderId *uuid.UUID
-	var status *order.Status
-	for _, market := range markets {
-		if market.ID.String() == marketId {
-			ok = true
-			orderId, status, err = s.repo.CreateOrder(mapOrder)
+	switch {
+	case pageSize < 0:
+		return nil, "", status.Errorf(codes.InvalidArgument, "page_size must not be negative, got %d", pageSize)
+	case pageSize == 0:
+		pageSize = defaultOrdersPageSize
+	case pageSize > maxOrdersPageSize:
+		pageSize = maxOrdersPageSize
+	}
+
+	var after uuid.UUID
+	if pageToken != "" {
+		if after, err = uuid.Parse(pageToken); err != nil {
+			return nil, "", status.Errorf(codes.InvalidArgument, "invalid page token %q", pageToken)
 		}
 	}
 
-	if !ok {
-		return "", nil, fmt.Errorf("needed market not found")
+	orders, more, err := s.repo.ListOrders(userId, filter, after, pageSize)
+	if err != nil {
+		return nil, "", repoError(err)
+	}
+	if !more {
+		return orders, "", nil
 	}
-	return orderId.String(), status, nil
+	return orders, orders[len(orders)-1].ID.String(), nil
 }
 
-func (s *OrderService) GetOrderStatus(userIdString, orderIdString string) (*order.Status, error) {
+func (s *OrderService) CancelOrder(userIdString, orderIdString, clientOrderId string, expectedVersion int64) (*models.Order, error) {
 	userId, err := uuid.Parse(userIdString)
 	if err != nil {
 		s.logger.Error("failed parse userId", slog.String("error", err.Error()))
-		return nil, err
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user id %q", userIdString)
 	}
-	orderId, err := uuid.Parse(orderIdString)
+	target, err := cancelTarget(orderIdString, clientOrderId, expectedVersion)
 	if err != nil {
-		s.logger.Error("failed parse orderId", slog.String("error", err.Error()))
 		return nil, err
 	}
 
-	status, err := s.repo.GetOrderStatus(userId, orderId)
+	//client order id and market mode are checked under the repository lock
+	cancelled, err := s.repo.CancelOrder(userId, target, s.allowCancel)
 	if err != nil {
-		s.logger.Error("error get order status from repo", slog.String("error", err.Error()))
-		return nil, err
+		return nil, repoError(err)
 	}
+	s.onClosed(cancelled)
+	return cancelled, nil
+}
 
-	return status, nil
+// resolveOrderId parses the ids of a request that addresses an order either by
+// order id or, when that is empty, by client order id.
+func (s *OrderService) resolveOrderId(userIdString, orderIdString, clientOrderId string) (uuid.UUID, uuid.UUID, error) {
+	userId, err := uuid.Parse(userIdString)
+	if err != nil {
+		s.logger.Error("failed parse userId", slog.String("error", err.Error()))
+		return uuid.Nil, uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid user id %q", userIdString)
+	}
 
+	if orderIdString == "" {
+		if clientOrderId == "" {
+			return uuid.Nil, uuid.Nil, status.Error(codes.InvalidArgument, "order_id or client_order_id is required")
+		}
+		orderId, err := s.repo.FindByClientOrderId(userId, clientOrderId)
+		if err != nil {
+			return uuid.Nil, uuid.Nil, repoError(err)
+		}
+		return userId, orderId, nil
+	}
+
+	orderId, err := uuid.Parse(orderIdString)
+	if err != nil {
+		s.logger.Error("failed parse orderId", slog.String("error", err.Error()))
+		return uuid.Nil, uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid order id %q", orderIdString)
+	}
+	return userId, orderId, nil
 }
 
-func (s *OrderService) StreamOrderUpdates(ctx context.Context) (*order.OrderStatusUpdateResponse, error) {
-	s.repo.UpdateAllOrdersToLast()
+// StreamOrderUpdates calls send with every order change of userIdString, or of every
+// user when it is empty, until ctx is done or send fails. With cancelOnDisconnect the
+// stream is a session of the user announced through sendSession before any update.
+func (s *OrderService) StreamOrderUpdates(
+	ctx context.Context,
+	userIdString string,
+	cancelOnDisconnect bool,
+	sendSession func(sessionId string) error,
+	send func(models.Order) error,
+) error {
+	var filter events.Filter
+	if userIdString != "" {
+		userId, err := uuid.Parse(userIdString)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid user id %q", userIdString)
+		}
+		filter.UserId = userId
+	}
+	if cancelOnDisconnect && filter.UserId == uuid.Nil {
+		return status.Error(codes.InvalidArgument, "cancel_on_disconnect requires user_id")
+	}
+
+	updates, unsubscribe := s.broker.Subscribe(filter)
+	defer unsubscribe()
+
+	if cancelOnDisconnect {
+		sessionId := s.disconnects.Open(filter.UserId)
+		defer func() {
+			//only a client that went away schedules the cancel, not a stream the server closed
+			s.disconnects.End(sessionId, ctx.Err() != nil)
+		}()
+		if err := sendSession(sessionId.String()); err != nil {
+			return err
+		}
+	}
 
 	for {
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-time.After(5 * time.Second):
-			orders := s.repo.GetOrders()
-			for id, ord := range orders {
-				if s.repo.HasChanged(id) {
-					update := &order.OrderStatusUpdateResponse{
-						Status: ord.Status,
-					}
-					s.repo.UpdateLastState(id)
-					return update, nil
-				}
+			return ctx.Err()
+		case update, ok := <-updates:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "order updates stream fell behind, resubscribe")
+			}
+			if err := send(update); err != nil {
+				return err
 			}
 		}
 	}
 }
 
-func (s *OrderService) UpdateOrderStatus(id string, status *order.Status) (*order.Status, error) {
-	s.repo.UpdateOrderStatus(id, *status)
-	return status, nil
+// UpdateOrderStatus sets the status of an order, a non zero expectedVersion has to
+// match the current version of the order or the update fails with Aborted.
+func (s *OrderService) UpdateOrderStatus(id string, status *order.Status, expectedVersion int64) (*models.Order, error) {
+	updated, err := s.repo.UpdateOrderStatus(id, *status, expectedVersion)
+	if err != nil {
+		return nil, repoError(err)
+	}
+	s.publishUpdate(updated)
+	return updated, nil
 }
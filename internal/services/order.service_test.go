@@ -0,0 +1,21 @@
+package services
+
+import (
+	"context"
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestRefreshMarketCatalogRequiresAdmin(t *testing.T) {
+	s := newTestService(t)
+
+	for _, role := range []pkg.UserRole{pkg.UserRole_USER_ROLE_UNSPECIFIED, pkg.UserRole_USER_ROLE_BASIC, pkg.UserRole_USER_ROLE_PROFESSIONAL} {
+		//the refreshed roles must not grant the caller anything
+		_, err := s.RefreshMarketCatalog(context.Background(), role, []pkg.UserRole{pkg.UserRole_USER_ROLE_ADMIN})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("caller %v err %v, want PermissionDenied", role, err)
+		}
+	}
+}
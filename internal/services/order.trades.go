@@ -0,0 +1,57 @@
+package services
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strconv"
+)
+
+const (
+	defaultTradesPageSize = 100
+	maxTradesPageSize     = 1000
+)
+
+// ListTrades pages through the trades of a user, a market or a user in a market,
+// oldest first. The returned page token is empty on the last page.
+func (s *OrderService) ListTrades(userIdString, marketIdString string, pageSize int, pageToken string) ([]*models.Trade, string, error) {
+	if userIdString == "" && marketIdString == "" {
+		return nil, "", status.Error(codes.InvalidArgument, "user_id or market_id is required")
+	}
+
+	var userId, marketId uuid.UUID
+	var err error
+	if userIdString != "" {
+		if userId, err = uuid.Parse(userIdString); err != nil {
+			return nil, "", status.Errorf(codes.InvalidArgument, "invalid user id %q", userIdString)
+		}
+	}
+	if marketIdString != "" {
+		if marketId, err = uuid.Parse(marketIdString); err != nil {
+			return nil, "", status.Errorf(codes.InvalidArgument, "invalid market id %q", marketIdString)
+		}
+	}
+
+	switch {
+	case pageSize < 0:
+		return nil, "", status.Errorf(codes.InvalidArgument, "page_size must not be negative, got %d", pageSize)
+	case pageSize == 0:
+		pageSize = defaultTradesPageSize
+	case pageSize > maxTradesPageSize:
+		pageSize = maxTradesPageSize
+	}
+
+	offset := 0
+	if pageToken != "" {
+		if offset, err = strconv.Atoi(pageToken); err != nil || offset < 0 {
+			return nil, "", status.Errorf(codes.InvalidArgument, "invalid page token %q", pageToken)
+		}
+	}
+
+	trades, next := s.trades.ListTrades(userId, marketId, offset, pageSize)
+	if next < 0 {
+		return trades, "", nil
+	}
+	return trades, strconv.Itoa(next), nil
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/marketdata"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/ewik2k21/grpcOrderService/internal/trigger"
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"log/slog"
+)
+
+// holdStop parks an untriggered stop order in the trigger book, or releases it at
+// once when the latest market price already crosses its stop price. The order is
+// parked before the price is read, so a price that onPrice sets concurrently either
+// triggers it from the book or is seen here.
+func (s *OrderService) holdStop(stopOrder *models.Order) *models.Order {
+	s.triggers.Add(stopOrder.MarketId, stopOrder.ID, stopOrder.Side, stopOrder.StopPrice)
+	price, ok := s.prices.Latest(stopOrder.MarketId)
+	if !ok || !trigger.Crosses(stopOrder.Side, stopOrder.StopPrice, price.Value) {
+		return stopOrder
+	}
+	if !s.triggers.Remove(stopOrder.MarketId, stopOrder.ID) {
+		//onPrice took it from the book already
+		if current, err := s.repo.GetOrder(stopOrder.UserId, stopOrder.ID); err == nil {
+			return current
+		}
+		return stopOrder
+	}
+	if triggered := s.triggerStop(stopOrder.ID, price); triggered != nil {
+		return triggered
+	}
+	return stopOrder
+}
+
+// onPrice records a new market price and releases every stop order it crosses.
+func (s *OrderService) onPrice(marketId uuid.UUID, price marketdata.Price) []uuid.UUID {
+	s.prices.Set(marketId, price)
+
+	triggeredIds := make([]uuid.UUID, 0)
+	if !s.marketStates.Get(marketId).AcceptsOrders() {
+		//stops keep waiting until the market trades again
+		return triggeredIds
+	}
+	for _, orderId := range s.triggers.Trigger(marketId, price.Value) {
+		if s.triggerStop(orderId, price) != nil {
+			triggeredIds = append(triggeredIds, orderId)
+		}
+	}
+	return triggeredIds
+}
+
+func (s *OrderService) triggerStop(orderId uuid.UUID, price marketdata.Price) *models.Order {
+	triggered, err := s.repo.TriggerOrder(orderId, price.Value, price.Source.String())
+	if err != nil {
+		//cancelled or expired while the price moved
+		return nil
+	}
+	s.logger.Info("stop order released",
+		slog.String("order_id", orderId.String()),
+		slog.Float64("price", price.Value))
+	s.broker.Publish(triggered)
+	return s.release(triggered)
+}
+
+// SetReferencePrice updates the reference price of a market and returns the stop orders it triggered.
+func (s *OrderService) SetReferencePrice(userRole pkg.UserRole, marketIdString string, price float64) ([]uuid.UUID, error) {
+	if err := requireAdmin(userRole, "set reference prices"); err != nil {
+		return nil, err
+	}
+	marketId, err := uuid.Parse(marketIdString)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid market id %q", marketIdString)
+	}
+	if price <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "price must be positive")
+	}
+
+	return s.onPrice(marketId, marketdata.Price{
+		Value:     price,
+		Source:    marketdata.SourceReference,
+		UpdatedAt: s.clock.Now(),
+	}), nil
+}
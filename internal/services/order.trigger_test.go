@@ -0,0 +1,63 @@
+package services
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/marketdata"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sync"
+	"testing"
+)
+
+// TestHoldStopRacingPrice places stops while a crossing price arrives, no stop may be
+// left waiting for a price that already crossed it.
+func TestHoldStopRacingPrice(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		s := newTestService(t)
+		stop := &models.Order{
+			UserId:    uuid.New(),
+			OrderType: order.OrderType_STOP_MARKET,
+			Side:      order.Side_SELL,
+			StopPrice: 100,
+			Quantity:  1,
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			placeOrder(t, s, stop)
+		}()
+		go func() {
+			defer wg.Done()
+			s.onPrice(testMarket.ID, marketdata.Price{Value: 90, Source: marketdata.SourceLastTrade})
+		}()
+		wg.Wait()
+
+		stored, err := s.repo.GetOrder(stop.UserId, stop.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.Status == order.Status_UNTRIGGERED {
+			t.Fatalf("run %d: stop at 100 still untriggered after the price fell to 90", i)
+		}
+	}
+}
+
+func TestSetReferencePriceRequiresAdmin(t *testing.T) {
+	s := newTestService(t)
+	marketId := testMarket.ID.String()
+
+	if _, err := s.SetReferencePrice(pkg.UserRole_USER_ROLE_BASIC, marketId, 100); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("non admin err %v, want PermissionDenied", err)
+	}
+	if _, ok := s.prices.Latest(testMarket.ID); ok {
+		t.Fatal("non admin moved the reference price")
+	}
+	if _, err := s.SetReferencePrice(pkg.UserRole_USER_ROLE_ADMIN, marketId, 100); err != nil {
+		t.Fatalf("admin err %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"github.com/ewik2k21/grpcOrderService/internal/ledger"
+	"github.com/ewik2k21/grpcOrderService/internal/mappers"
+	"github.com/ewik2k21/grpcOrderService/internal/models"
+	"github.com/ewik2k21/grpcOrderService/internal/repositories"
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	pkg "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OrderCheck is the outcome of a dry run of CreateOrder.
+type OrderCheck struct {
+	Violations []error
+	MakerRate  float64
+	TakerRate  float64
+	// EstimatedFee is the fee in FeeAsset when the whole order fills as taker
+	EstimatedFee float64
+	FeeAsset     string
+}
+
+// ValidateOrder runs the CreateOrder pipeline on request without placing the order:
+// market lookup, trading rules, risk checks and funds. It collects every violation
+// instead of stopping at the first and estimates the fees. Nothing is stored,
+// reserved or published.
+func (s *OrderService) ValidateOrder(userRole pkg.UserRole, request *order.CreateOrderRequest) (*OrderCheck, error) {
+	userId, err := uuid.Parse(request.GetUserId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user id %q", request.GetUserId())
+	}
+
+	check := &OrderCheck{}
+	market, err := CheckMarkets(s.catalog, s.marketStates, userRole, request.GetMarketId())
+	if status.Code(err) == codes.Unavailable {
+		return nil, err
+	}
+	if err != nil {
+		check.Violations = append(check.Violations, err)
+	}
+
+	mapOrder, err := mappers.MapProtoToOrder(request)
+	if err != nil {
+		//the market id is broken, CheckMarkets reported it already
+		return check, nil
+	}
+	check.Violations = append(check.Violations, s.orderViolations(mapOrder)...)
+	if mapOrder.ClientOrderId != "" {
+		if s.repo.ClientOrderIdInUse(userId, mapOrder.ClientOrderId) {
+			check.Violations = append(check.Violations, repoError(repositories.ErrClientOrderIdInUse))
+		}
+	}
+	check.Violations = append(check.Violations, s.riskViolations(userRole, mapOrder)...)
+
+	if market != nil {
+		if err = s.checkFunds(market, mapOrder); err != nil {
+			check.Violations = append(check.Violations, err)
+		}
+		s.estimateFees(check, market, mapOrder)
+	}
+	return check, nil
+}
+
+// checkFunds reports whether the available balance covers what the order would reserve.
+func (s *OrderService) checkFunds(market *models.Market, o *models.Order) error {
+	asset, amount, err := s.reservation(market, o)
+	if err != nil {
+		return err
+	}
+	if amount <= 0 {
+		return ledgerError(ledger.ErrInvalidAmount)
+	}
+	if available := s.ledger.Balance(o.UserId, asset).Available; available < amount-models.QuantityEpsilon {
+		return failedPrecondition(ReasonNoFunds, "%v %s available, the order needs %v", available, asset, amount)
+	}
+	return nil
+}
+
+// estimateFees fills the fee rates of the user and the fee of o filled completely as
+// taker. Buys pay in the base asset, sells in the quote asset at the reservation price.
+func (s *OrderService) estimateFees(check *OrderCheck, market *models.Market, o *models.Order) {
+	base, quote, ok := market.Assets()
+	if !ok {
+		return
+	}
+	check.MakerRate, check.TakerRate = s.fees.Rates(o.UserRole, o.UserId, o.MarketId)
+	if o.Side == order.Side_BUY {
+		check.FeeAsset = base
+		check.EstimatedFee = o.Quantity * check.TakerRate
+		return
+	}
+
+	check.FeeAsset = quote
+	price, err := s.reservationPrice(o)
+	if err == nil {
+		check.EstimatedFee = price * o.Quantity * check.TakerRate
+	}
+}
@@ -0,0 +1,131 @@
+package trigger
+
+import (
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"github.com/google/uuid"
+	"slices"
+	"sync"
+)
+
+type stop struct {
+	orderId   uuid.UUID
+	stopPrice float64
+	seq       uint64
+}
+
+// marketStops keeps buy stops ascending and sell stops descending by stop price,
+// so the stops a price move crosses are always at the front.
+type marketStops struct {
+	buys  []stop
+	sells []stop
+}
+
+// Book holds untriggered stop orders per market until a price crosses their stop price.
+type Book struct {
+	mu      sync.Mutex
+	seq     uint64
+	markets map[uuid.UUID]*marketStops
+}
+
+func NewBook() *Book {
+	return &Book{
+		markets: make(map[uuid.UUID]*marketStops),
+	}
+}
+
+// Crosses reports whether price triggers a stop of side at stopPrice.
+func Crosses(side order.Side, stopPrice, price float64) bool {
+	if side == order.Side_BUY {
+		return price >= stopPrice
+	}
+	return price <= stopPrice
+}
+
+func (b *Book) Add(marketId, orderId uuid.UUID, side order.Side, stopPrice float64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stops, ok := b.markets[marketId]
+	if !ok {
+		stops = &marketStops{}
+		b.markets[marketId] = stops
+	}
+
+	b.seq++
+	entry := stop{orderId: orderId, stopPrice: stopPrice, seq: b.seq}
+	if side == order.Side_BUY {
+		i, _ := slices.BinarySearchFunc(stops.buys, entry, func(a, b stop) int {
+			return compare(a.stopPrice, b.stopPrice, a.seq, b.seq)
+		})
+		stops.buys = slices.Insert(stops.buys, i, entry)
+		return
+	}
+	i, _ := slices.BinarySearchFunc(stops.sells, entry, func(a, b stop) int {
+		return compare(b.stopPrice, a.stopPrice, a.seq, b.seq)
+	})
+	stops.sells = slices.Insert(stops.sells, i, entry)
+}
+
+// Remove drops an untriggered stop, it reports false when the order was not in the book.
+func (b *Book) Remove(marketId, orderId uuid.UUID) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stops, ok := b.markets[marketId]
+	if !ok {
+		return false
+	}
+	match := func(s stop) bool { return s.orderId == orderId }
+	if i := slices.IndexFunc(stops.buys, match); i >= 0 {
+		stops.buys = slices.Delete(stops.buys, i, i+1)
+		return true
+	}
+	if i := slices.IndexFunc(stops.sells, match); i >= 0 {
+		stops.sells = slices.Delete(stops.sells, i, i+1)
+		return true
+	}
+	return false
+}
+
+// Trigger removes and returns, in trigger order, every stop of marketId crossed by price.
+func (b *Book) Trigger(marketId uuid.UUID, price float64) []uuid.UUID {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stops, ok := b.markets[marketId]
+	if !ok {
+		return nil
+	}
+
+	var triggered []uuid.UUID
+	n := 0
+	for n < len(stops.buys) && Crosses(order.Side_BUY, stops.buys[n].stopPrice, price) {
+		triggered = append(triggered, stops.buys[n].orderId)
+		n++
+	}
+	stops.buys = stops.buys[n:]
+
+	n = 0
+	for n < len(stops.sells) && Crosses(order.Side_SELL, stops.sells[n].stopPrice, price) {
+		triggered = append(triggered, stops.sells[n].orderId)
+		n++
+	}
+	stops.sells = stops.sells[n:]
+
+	return triggered
+}
+
+func compare(priceA, priceB float64, seqA, seqB uint64) int {
+	switch {
+	case priceA < priceB:
+		return -1
+	case priceA > priceB:
+		return 1
+	case seqA < seqB:
+		return -1
+	case seqA > seqB:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,68 @@
+package trigger
+
+import (
+	order "github.com/ewik2k21/grpcOrderService/pkg/order_service_v1"
+	"github.com/google/uuid"
+	"slices"
+	"testing"
+)
+
+func TestBookTrigger(t *testing.T) {
+	type stop struct {
+		side      order.Side
+		stopPrice float64
+	}
+	tests := []struct {
+		name  string
+		stops []stop
+		price float64
+		// indexes into stops in trigger order
+		want []int
+	}{
+		{name: "buy stops trigger at or above", stops: []stop{{order.Side_BUY, 100}, {order.Side_BUY, 110}}, price: 100, want: []int{0}},
+		{name: "sell stops trigger at or below", stops: []stop{{order.Side_SELL, 90}, {order.Side_SELL, 80}}, price: 85, want: []int{0}},
+		{name: "nearest stop first", stops: []stop{{order.Side_BUY, 105}, {order.Side_BUY, 100}}, price: 110, want: []int{1, 0}},
+		{name: "same stop price keeps arrival order", stops: []stop{{order.Side_SELL, 90}, {order.Side_SELL, 90}}, price: 90, want: []int{0, 1}},
+		{name: "buys before sells", stops: []stop{{order.Side_SELL, 100}, {order.Side_BUY, 100}}, price: 100, want: []int{1, 0}},
+		{name: "nothing crossed", stops: []stop{{order.Side_BUY, 100}, {order.Side_SELL, 90}}, price: 95},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book := NewBook()
+			marketId := uuid.New()
+			ids := make([]uuid.UUID, len(tt.stops))
+			for i, s := range tt.stops {
+				ids[i] = uuid.New()
+				book.Add(marketId, ids[i], s.side, s.stopPrice)
+			}
+
+			want := make([]uuid.UUID, 0, len(tt.want))
+			for _, i := range tt.want {
+				want = append(want, ids[i])
+			}
+			got := book.Trigger(marketId, tt.price)
+			if !slices.Equal(got, want) && !(len(got) == 0 && len(want) == 0) {
+				t.Fatalf("triggered %v, want %v", got, want)
+			}
+			if again := book.Trigger(marketId, tt.price); len(again) != 0 {
+				t.Fatalf("triggered %v twice", again)
+			}
+		})
+	}
+}
+
+func TestBookRemove(t *testing.T) {
+	book := NewBook()
+	marketId, orderId := uuid.New(), uuid.New()
+	book.Add(marketId, orderId, order.Side_BUY, 100)
+
+	if !book.Remove(marketId, orderId) {
+		t.Fatal("remove of a parked stop reported false")
+	}
+	if book.Remove(marketId, orderId) {
+		t.Fatal("second remove reported true")
+	}
+	if got := book.Trigger(marketId, 200); len(got) != 0 {
+		t.Fatalf("removed stop triggered: %v", got)
+	}
+}
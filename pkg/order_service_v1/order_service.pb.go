@@ -24,37 +24,162 @@ var File_order_service_v1_order_service_proto protoreflect.FileDescriptor
 
 const file_order_service_v1_order_service_proto_rawDesc = "" +
 	"\n" +
-	"$order_service_v1/order_service.proto\x12\x10order_service_v1\x1a-order_service_v1/order_service_messages.proto2\xaf\x03\n" +
+	"$order_service_v1/order_service.proto\x12\x10order_service_v1\x1a-order_service_v1/order_service_messages.proto2\xde\x15\n" +
 	"\fOrderService\x12c\n" +
 	"\x0eGetOrderStatus\x12'.order_service_v1.GetOrderStatusRequest\x1a(.order_service_v1.GetOrderStatusResponse\x12Z\n" +
-	"\vCreateOrder\x12$.order_service_v1.CreateOrderRequest\x1a%.order_service_v1.CreateOrderResponse\x12p\n" +
-	"\x12StreamOrderUpdates\x12+.order_service_v1.StreamOrderUpdatesRequest\x1a+.order_service_v1.OrderStatusUpdateResponse0\x01\x12l\n" +
-	"\x11UpdateOrderStatus\x12*.order_service_v1.UpdateOrderStatusRequest\x1a+.order_service_v1.UpdateOrderStatusResponseB*Z(github.com/ewik2k21/grpcOrderService/pkgb\x06proto3"
+	"\vCreateOrder\x12$.order_service_v1.CreateOrderRequest\x1a%.order_service_v1.CreateOrderResponse\x12`\n" +
+	"\rValidateOrder\x12&.order_service_v1.ValidateOrderRequest\x1a'.order_service_v1.ValidateOrderResponse\x12Q\n" +
+	"\bGetOrder\x12!.order_service_v1.GetOrderRequest\x1a\".order_service_v1.GetOrderResponse\x12W\n" +
+	"\n" +
+	"ListOrders\x12#.order_service_v1.ListOrdersRequest\x1a$.order_service_v1.ListOrdersResponse\x12Z\n" +
+	"\vCancelOrder\x12$.order_service_v1.CancelOrderRequest\x1a%.order_service_v1.CancelOrderResponse\x12]\n" +
+	"\fCreateOrders\x12%.order_service_v1.CreateOrdersRequest\x1a&.order_service_v1.CreateOrdersResponse\x12]\n" +
+	"\fCancelOrders\x12%.order_service_v1.CancelOrdersRequest\x1a&.order_service_v1.CancelOrdersResponse\x12W\n" +
+	"\n" +
+	"MassCancel\x12#.order_service_v1.MassCancelRequest\x1a$.order_service_v1.MassCancelResponse\x12W\n" +
+	"\n" +
+	"AmendOrder\x12#.order_service_v1.AmendOrderRequest\x1a$.order_service_v1.AmendOrderResponse\x12p\n" +
+	"\x12StreamOrderUpdates\x12+.order_service_v1.StreamOrderUpdatesRequest\x1a+.order_service_v1.OrderStatusUpdateResponse0\x01\x12\x81\x01\n" +
+	"\x18CloseOrderUpdatesSession\x121.order_service_v1.CloseOrderUpdatesSessionRequest\x1a2.order_service_v1.CloseOrderUpdatesSessionResponse\x12c\n" +
+	"\x0eCancelAllAfter\x12'.order_service_v1.CancelAllAfterRequest\x1a(.order_service_v1.CancelAllAfterResponse\x12l\n" +
+	"\x11UpdateOrderStatus\x12*.order_service_v1.UpdateOrderStatusRequest\x1a+.order_service_v1.UpdateOrderStatusResponse\x12W\n" +
+	"\n" +
+	"ListTrades\x12#.order_service_v1.ListTradesRequest\x1a$.order_service_v1.ListTradesResponse\x12]\n" +
+	"\fGetOrderBook\x12%.order_service_v1.GetOrderBookRequest\x1a&.order_service_v1.GetOrderBookResponse\x12`\n" +
+	"\x0fStreamOrderBook\x12(.order_service_v1.StreamOrderBookRequest\x1a!.order_service_v1.OrderBookUpdate0\x01\x12]\n" +
+	"\fGetPositions\x12%.order_service_v1.GetPositionsRequest\x1a&.order_service_v1.GetPositionsResponse\x12_\n" +
+	"\x0fStreamPositions\x12(.order_service_v1.StreamPositionsRequest\x1a .order_service_v1.PositionUpdate0\x01\x12Z\n" +
+	"\vGetBalances\x12$.order_service_v1.GetBalancesRequest\x1a%.order_service_v1.GetBalancesResponse\x12`\n" +
+	"\rCreditAccount\x12&.order_service_v1.CreditAccountRequest\x1a'.order_service_v1.CreditAccountResponse\x12]\n" +
+	"\fDebitAccount\x12%.order_service_v1.DebitAccountRequest\x1a&.order_service_v1.DebitAccountResponse\x12W\n" +
+	"\n" +
+	"HaltMarket\x12#.order_service_v1.HaltMarketRequest\x1a$.order_service_v1.HaltMarketResponse\x12]\n" +
+	"\fResumeMarket\x12%.order_service_v1.ResumeMarketRequest\x1a&.order_service_v1.ResumeMarketResponse\x12r\n" +
+	"\x13SetMarketCancelOnly\x12,.order_service_v1.SetMarketCancelOnlyRequest\x1a-.order_service_v1.SetMarketCancelOnlyResponse\x12b\n" +
+	"\x12StreamMarketStates\x12+.order_service_v1.StreamMarketStatesRequest\x1a\x1d.order_service_v1.MarketState0\x01\x12u\n" +
+	"\x14RefreshMarketCatalog\x12-.order_service_v1.RefreshMarketCatalogRequest\x1a..order_service_v1.RefreshMarketCatalogResponse\x12l\n" +
+	"\x11SetReferencePrice\x12*.order_service_v1.SetReferencePriceRequest\x1a+.order_service_v1.SetReferencePriceResponseB*Z(github.com/ewik2k21/grpcOrderService/pkgb\x06proto3"
 
 var file_order_service_v1_order_service_proto_goTypes = []any{
-	(*GetOrderStatusRequest)(nil),     // 0: order_service_v1.GetOrderStatusRequest
-	(*CreateOrderRequest)(nil),        // 1: order_service_v1.CreateOrderRequest
-	(*StreamOrderUpdatesRequest)(nil), // 2: order_service_v1.StreamOrderUpdatesRequest
-	(*UpdateOrderStatusRequest)(nil),  // 3: order_service_v1.UpdateOrderStatusRequest
-	(*GetOrderStatusResponse)(nil),    // 4: order_service_v1.GetOrderStatusResponse
-	(*CreateOrderResponse)(nil),       // 5: order_service_v1.CreateOrderResponse
-	(*OrderStatusUpdateResponse)(nil), // 6: order_service_v1.OrderStatusUpdateResponse
-	(*UpdateOrderStatusResponse)(nil), // 7: order_service_v1.UpdateOrderStatusResponse
+	(*GetOrderStatusRequest)(nil),            // 0: order_service_v1.GetOrderStatusRequest
+	(*CreateOrderRequest)(nil),               // 1: order_service_v1.CreateOrderRequest
+	(*ValidateOrderRequest)(nil),             // 2: order_service_v1.ValidateOrderRequest
+	(*GetOrderRequest)(nil),                  // 3: order_service_v1.GetOrderRequest
+	(*ListOrdersRequest)(nil),                // 4: order_service_v1.ListOrdersRequest
+	(*CancelOrderRequest)(nil),               // 5: order_service_v1.CancelOrderRequest
+	(*CreateOrdersRequest)(nil),              // 6: order_service_v1.CreateOrdersRequest
+	(*CancelOrdersRequest)(nil),              // 7: order_service_v1.CancelOrdersRequest
+	(*MassCancelRequest)(nil),                // 8: order_service_v1.MassCancelRequest
+	(*AmendOrderRequest)(nil),                // 9: order_service_v1.AmendOrderRequest
+	(*StreamOrderUpdatesRequest)(nil),        // 10: order_service_v1.StreamOrderUpdatesRequest
+	(*CloseOrderUpdatesSessionRequest)(nil),  // 11: order_service_v1.CloseOrderUpdatesSessionRequest
+	(*CancelAllAfterRequest)(nil),            // 12: order_service_v1.CancelAllAfterRequest
+	(*UpdateOrderStatusRequest)(nil),         // 13: order_service_v1.UpdateOrderStatusRequest
+	(*ListTradesRequest)(nil),                // 14: order_service_v1.ListTradesRequest
+	(*GetOrderBookRequest)(nil),              // 15: order_service_v1.GetOrderBookRequest
+	(*StreamOrderBookRequest)(nil),           // 16: order_service_v1.StreamOrderBookRequest
+	(*GetPositionsRequest)(nil),              // 17: order_service_v1.GetPositionsRequest
+	(*StreamPositionsRequest)(nil),           // 18: order_service_v1.StreamPositionsRequest
+	(*GetBalancesRequest)(nil),               // 19: order_service_v1.GetBalancesRequest
+	(*CreditAccountRequest)(nil),             // 20: order_service_v1.CreditAccountRequest
+	(*DebitAccountRequest)(nil),              // 21: order_service_v1.DebitAccountRequest
+	(*HaltMarketRequest)(nil),                // 22: order_service_v1.HaltMarketRequest
+	(*ResumeMarketRequest)(nil),              // 23: order_service_v1.ResumeMarketRequest
+	(*SetMarketCancelOnlyRequest)(nil),       // 24: order_service_v1.SetMarketCancelOnlyRequest
+	(*StreamMarketStatesRequest)(nil),        // 25: order_service_v1.StreamMarketStatesRequest
+	(*RefreshMarketCatalogRequest)(nil),      // 26: order_service_v1.RefreshMarketCatalogRequest
+	(*SetReferencePriceRequest)(nil),         // 27: order_service_v1.SetReferencePriceRequest
+	(*GetOrderStatusResponse)(nil),           // 28: order_service_v1.GetOrderStatusResponse
+	(*CreateOrderResponse)(nil),              // 29: order_service_v1.CreateOrderResponse
+	(*ValidateOrderResponse)(nil),            // 30: order_service_v1.ValidateOrderResponse
+	(*GetOrderResponse)(nil),                 // 31: order_service_v1.GetOrderResponse
+	(*ListOrdersResponse)(nil),               // 32: order_service_v1.ListOrdersResponse
+	(*CancelOrderResponse)(nil),              // 33: order_service_v1.CancelOrderResponse
+	(*CreateOrdersResponse)(nil),             // 34: order_service_v1.CreateOrdersResponse
+	(*CancelOrdersResponse)(nil),             // 35: order_service_v1.CancelOrdersResponse
+	(*MassCancelResponse)(nil),               // 36: order_service_v1.MassCancelResponse
+	(*AmendOrderResponse)(nil),               // 37: order_service_v1.AmendOrderResponse
+	(*OrderStatusUpdateResponse)(nil),        // 38: order_service_v1.OrderStatusUpdateResponse
+	(*CloseOrderUpdatesSessionResponse)(nil), // 39: order_service_v1.CloseOrderUpdatesSessionResponse
+	(*CancelAllAfterResponse)(nil),           // 40: order_service_v1.CancelAllAfterResponse
+	(*UpdateOrderStatusResponse)(nil),        // 41: order_service_v1.UpdateOrderStatusResponse
+	(*ListTradesResponse)(nil),               // 42: order_service_v1.ListTradesResponse
+	(*GetOrderBookResponse)(nil),             // 43: order_service_v1.GetOrderBookResponse
+	(*OrderBookUpdate)(nil),                  // 44: order_service_v1.OrderBookUpdate
+	(*GetPositionsResponse)(nil),             // 45: order_service_v1.GetPositionsResponse
+	(*PositionUpdate)(nil),                   // 46: order_service_v1.PositionUpdate
+	(*GetBalancesResponse)(nil),              // 47: order_service_v1.GetBalancesResponse
+	(*CreditAccountResponse)(nil),            // 48: order_service_v1.CreditAccountResponse
+	(*DebitAccountResponse)(nil),             // 49: order_service_v1.DebitAccountResponse
+	(*HaltMarketResponse)(nil),               // 50: order_service_v1.HaltMarketResponse
+	(*ResumeMarketResponse)(nil),             // 51: order_service_v1.ResumeMarketResponse
+	(*SetMarketCancelOnlyResponse)(nil),      // 52: order_service_v1.SetMarketCancelOnlyResponse
+	(*MarketState)(nil),                      // 53: order_service_v1.MarketState
+	(*RefreshMarketCatalogResponse)(nil),     // 54: order_service_v1.RefreshMarketCatalogResponse
+	(*SetReferencePriceResponse)(nil),        // 55: order_service_v1.SetReferencePriceResponse
 }
 var file_order_service_v1_order_service_proto_depIdxs = []int32{
-	0, // 0: order_service_v1.OrderService.GetOrderStatus:input_type -> order_service_v1.GetOrderStatusRequest
-	1, // 1: order_service_v1.OrderService.CreateOrder:input_type -> order_service_v1.CreateOrderRequest
-	2, // 2: order_service_v1.OrderService.StreamOrderUpdates:input_type -> order_service_v1.StreamOrderUpdatesRequest
-	3, // 3: order_service_v1.OrderService.UpdateOrderStatus:input_type -> order_service_v1.UpdateOrderStatusRequest
-	4, // 4: order_service_v1.OrderService.GetOrderStatus:output_type -> order_service_v1.GetOrderStatusResponse
-	5, // 5: order_service_v1.OrderService.CreateOrder:output_type -> order_service_v1.CreateOrderResponse
-	6, // 6: order_service_v1.OrderService.StreamOrderUpdates:output_type -> order_service_v1.OrderStatusUpdateResponse
-	7, // 7: order_service_v1.OrderService.UpdateOrderStatus:output_type -> order_service_v1.UpdateOrderStatusResponse
-	4, // [4:8] is the sub-list for method output_type
-	0, // [0:4] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: order_service_v1.OrderService.GetOrderStatus:input_type -> order_service_v1.GetOrderStatusRequest
+	1,  // 1: order_service_v1.OrderService.CreateOrder:input_type -> order_service_v1.CreateOrderRequest
+	2,  // 2: order_service_v1.OrderService.ValidateOrder:input_type -> order_service_v1.ValidateOrderRequest
+	3,  // 3: order_service_v1.OrderService.GetOrder:input_type -> order_service_v1.GetOrderRequest
+	4,  // 4: order_service_v1.OrderService.ListOrders:input_type -> order_service_v1.ListOrdersRequest
+	5,  // 5: order_service_v1.OrderService.CancelOrder:input_type -> order_service_v1.CancelOrderRequest
+	6,  // 6: order_service_v1.OrderService.CreateOrders:input_type -> order_service_v1.CreateOrdersRequest
+	7,  // 7: order_service_v1.OrderService.CancelOrders:input_type -> order_service_v1.CancelOrdersRequest
+	8,  // 8: order_service_v1.OrderService.MassCancel:input_type -> order_service_v1.MassCancelRequest
+	9,  // 9: order_service_v1.OrderService.AmendOrder:input_type -> order_service_v1.AmendOrderRequest
+	10, // 10: order_service_v1.OrderService.StreamOrderUpdates:input_type -> order_service_v1.StreamOrderUpdatesRequest
+	11, // 11: order_service_v1.OrderService.CloseOrderUpdatesSession:input_type -> order_service_v1.CloseOrderUpdatesSessionRequest
+	12, // 12: order_service_v1.OrderService.CancelAllAfter:input_type -> order_service_v1.CancelAllAfterRequest
+	13, // 13: order_service_v1.OrderService.UpdateOrderStatus:input_type -> order_service_v1.UpdateOrderStatusRequest
+	14, // 14: order_service_v1.OrderService.ListTrades:input_type -> order_service_v1.ListTradesRequest
+	15, // 15: order_service_v1.OrderService.GetOrderBook:input_type -> order_service_v1.GetOrderBookRequest
+	16, // 16: order_service_v1.OrderService.StreamOrderBook:input_type -> order_service_v1.StreamOrderBookRequest
+	17, // 17: order_service_v1.OrderService.GetPositions:input_type -> order_service_v1.GetPositionsRequest
+	18, // 18: order_service_v1.OrderService.StreamPositions:input_type -> order_service_v1.StreamPositionsRequest
+	19, // 19: order_service_v1.OrderService.GetBalances:input_type -> order_service_v1.GetBalancesRequest
+	20, // 20: order_service_v1.OrderService.CreditAccount:input_type -> order_service_v1.CreditAccountRequest
+	21, // 21: order_service_v1.OrderService.DebitAccount:input_type -> order_service_v1.DebitAccountRequest
+	22, // 22: order_service_v1.OrderService.HaltMarket:input_type -> order_service_v1.HaltMarketRequest
+	23, // 23: order_service_v1.OrderService.ResumeMarket:input_type -> order_service_v1.ResumeMarketRequest
+	24, // 24: order_service_v1.OrderService.SetMarketCancelOnly:input_type -> order_service_v1.SetMarketCancelOnlyRequest
+	25, // 25: order_service_v1.OrderService.StreamMarketStates:input_type -> order_service_v1.StreamMarketStatesRequest
+	26, // 26: order_service_v1.OrderService.RefreshMarketCatalog:input_type -> order_service_v1.RefreshMarketCatalogRequest
+	27, // 27: order_service_v1.OrderService.SetReferencePrice:input_type -> order_service_v1.SetReferencePriceRequest
+	28, // 28: order_service_v1.OrderService.GetOrderStatus:output_type -> order_service_v1.GetOrderStatusResponse
+	29, // 29: order_service_v1.OrderService.CreateOrder:output_type -> order_service_v1.CreateOrderResponse
+	30, // 30: order_service_v1.OrderService.ValidateOrder:output_type -> order_service_v1.ValidateOrderResponse
+	31, // 31: order_service_v1.OrderService.GetOrder:output_type -> order_service_v1.GetOrderResponse
+	32, // 32: order_service_v1.OrderService.ListOrders:output_type -> order_service_v1.ListOrdersResponse
+	33, // 33: order_service_v1.OrderService.CancelOrder:output_type -> order_service_v1.CancelOrderResponse
+	34, // 34: order_service_v1.OrderService.CreateOrders:output_type -> order_service_v1.CreateOrdersResponse
+	35, // 35: order_service_v1.OrderService.CancelOrders:output_type -> order_service_v1.CancelOrdersResponse
+	36, // 36: order_service_v1.OrderService.MassCancel:output_type -> order_service_v1.MassCancelResponse
+	37, // 37: order_service_v1.OrderService.AmendOrder:output_type -> order_service_v1.AmendOrderResponse
+	38, // 38: order_service_v1.OrderService.StreamOrderUpdates:output_type -> order_service_v1.OrderStatusUpdateResponse
+	39, // 39: order_service_v1.OrderService.CloseOrderUpdatesSession:output_type -> order_service_v1.CloseOrderUpdatesSessionResponse
+	40, // 40: order_service_v1.OrderService.CancelAllAfter:output_type -> order_service_v1.CancelAllAfterResponse
+	41, // 41: order_service_v1.OrderService.UpdateOrderStatus:output_type -> order_service_v1.UpdateOrderStatusResponse
+	42, // 42: order_service_v1.OrderService.ListTrades:output_type -> order_service_v1.ListTradesResponse
+	43, // 43: order_service_v1.OrderService.GetOrderBook:output_type -> order_service_v1.GetOrderBookResponse
+	44, // 44: order_service_v1.OrderService.StreamOrderBook:output_type -> order_service_v1.OrderBookUpdate
+	45, // 45: order_service_v1.OrderService.GetPositions:output_type -> order_service_v1.GetPositionsResponse
+	46, // 46: order_service_v1.OrderService.StreamPositions:output_type -> order_service_v1.PositionUpdate
+	47, // 47: order_service_v1.OrderService.GetBalances:output_type -> order_service_v1.GetBalancesResponse
+	48, // 48: order_service_v1.OrderService.CreditAccount:output_type -> order_service_v1.CreditAccountResponse
+	49, // 49: order_service_v1.OrderService.DebitAccount:output_type -> order_service_v1.DebitAccountResponse
+	50, // 50: order_service_v1.OrderService.HaltMarket:output_type -> order_service_v1.HaltMarketResponse
+	51, // 51: order_service_v1.OrderService.ResumeMarket:output_type -> order_service_v1.ResumeMarketResponse
+	52, // 52: order_service_v1.OrderService.SetMarketCancelOnly:output_type -> order_service_v1.SetMarketCancelOnlyResponse
+	53, // 53: order_service_v1.OrderService.StreamMarketStates:output_type -> order_service_v1.MarketState
+	54, // 54: order_service_v1.OrderService.RefreshMarketCatalog:output_type -> order_service_v1.RefreshMarketCatalogResponse
+	55, // 55: order_service_v1.OrderService.SetReferencePrice:output_type -> order_service_v1.SetReferencePriceResponse
+	28, // [28:56] is the sub-list for method output_type
+	0,  // [0:28] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_order_service_v1_order_service_proto_init() }
@@ -10,6 +10,7 @@ import (
 	spot_instrument_v1 "github.com/ewik2k21/grpcSpotInstrumentService/pkg/spot_instrument_v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -28,6 +29,10 @@ const (
 	Status_CREATED    Status = 0
 	Status_PROCESSING Status = 1
 	Status_PROCESSED  Status = 2
+	Status_CANCELLED  Status = 3
+	Status_EXPIRED    Status = 4
+	// stop order waiting for its stop price
+	Status_UNTRIGGERED Status = 5
 )
 
 // Enum value maps for Status.
@@ -36,11 +41,17 @@ var (
 		0: "CREATED",
 		1: "PROCESSING",
 		2: "PROCESSED",
+		3: "CANCELLED",
+		4: "EXPIRED",
+		5: "UNTRIGGERED",
 	}
 	Status_value = map[string]int32{
-		"CREATED":    0,
-		"PROCESSING": 1,
-		"PROCESSED":  2,
+		"CREATED":     0,
+		"PROCESSING":  1,
+		"PROCESSED":   2,
+		"CANCELLED":   3,
+		"EXPIRED":     4,
+		"UNTRIGGERED": 5,
 	}
 )
 
@@ -71,11 +82,212 @@ func (Status) EnumDescriptor() ([]byte, []int) {
 	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{0}
 }
 
+// why an order got its current status
+type StatusReason int32
+
+const (
+	StatusReason_NO_REASON        StatusReason = 0
+	StatusReason_FILLED           StatusReason = 1
+	StatusReason_PARTIALLY_FILLED StatusReason = 2
+	// the stop price was crossed
+	StatusReason_TRIGGERED          StatusReason = 3
+	StatusReason_CANCELLED_BY_USER  StatusReason = 4
+	StatusReason_CANCELLED_BY_ADMIN StatusReason = 5
+	// a cancel_on_disconnect StreamOrderUpdates session dropped
+	StatusReason_CANCELLED_ON_DISCONNECT StatusReason = 6
+	// the CancelAllAfter deadline ran out
+	StatusReason_CANCELLED_BY_DEAD_MAN_SWITCH StatusReason = 7
+	// expire_at of a GTD order passed
+	StatusReason_GTD_EXPIRED StatusReason = 8
+	// what a market or IOC order could not fill at once
+	StatusReason_UNFILLED_REMAINDER_EXPIRED StatusReason = 9
+	// the book could not fill a FOK order completely
+	StatusReason_FOK_NOT_FILLABLE StatusReason = 10
+	// set through UpdateOrderStatus
+	StatusReason_STATUS_UPDATED StatusReason = 11
+	// the following only come with ItemError of refused batch items, no order is stored for them
+	StatusReason_REJECTED_BY_RISK            StatusReason = 12
+	StatusReason_REJECTED_INSUFFICIENT_FUNDS StatusReason = 13
+	// the market is halted or cancel only
+	StatusReason_REJECTED_MARKET_HALTED StatusReason = 14
+)
+
+// Enum value maps for StatusReason.
+var (
+	StatusReason_name = map[int32]string{
+		0:  "NO_REASON",
+		1:  "FILLED",
+		2:  "PARTIALLY_FILLED",
+		3:  "TRIGGERED",
+		4:  "CANCELLED_BY_USER",
+		5:  "CANCELLED_BY_ADMIN",
+		6:  "CANCELLED_ON_DISCONNECT",
+		7:  "CANCELLED_BY_DEAD_MAN_SWITCH",
+		8:  "GTD_EXPIRED",
+		9:  "UNFILLED_REMAINDER_EXPIRED",
+		10: "FOK_NOT_FILLABLE",
+		11: "STATUS_UPDATED",
+		12: "REJECTED_BY_RISK",
+		13: "REJECTED_INSUFFICIENT_FUNDS",
+		14: "REJECTED_MARKET_HALTED",
+	}
+	StatusReason_value = map[string]int32{
+		"NO_REASON":                    0,
+		"FILLED":                       1,
+		"PARTIALLY_FILLED":             2,
+		"TRIGGERED":                    3,
+		"CANCELLED_BY_USER":            4,
+		"CANCELLED_BY_ADMIN":           5,
+		"CANCELLED_ON_DISCONNECT":      6,
+		"CANCELLED_BY_DEAD_MAN_SWITCH": 7,
+		"GTD_EXPIRED":                  8,
+		"UNFILLED_REMAINDER_EXPIRED":   9,
+		"FOK_NOT_FILLABLE":             10,
+		"STATUS_UPDATED":               11,
+		"REJECTED_BY_RISK":             12,
+		"REJECTED_INSUFFICIENT_FUNDS":  13,
+		"REJECTED_MARKET_HALTED":       14,
+	}
+)
+
+func (x StatusReason) Enum() *StatusReason {
+	p := new(StatusReason)
+	*p = x
+	return p
+}
+
+func (x StatusReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatusReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_service_v1_order_service_messages_proto_enumTypes[1].Descriptor()
+}
+
+func (StatusReason) Type() protoreflect.EnumType {
+	return &file_order_service_v1_order_service_messages_proto_enumTypes[1]
+}
+
+func (x StatusReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatusReason.Descriptor instead.
+func (StatusReason) EnumDescriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{1}
+}
+
+type Side int32
+
+const (
+	Side_BUY  Side = 0
+	Side_SELL Side = 1
+)
+
+// Enum value maps for Side.
+var (
+	Side_name = map[int32]string{
+		0: "BUY",
+		1: "SELL",
+	}
+	Side_value = map[string]int32{
+		"BUY":  0,
+		"SELL": 1,
+	}
+)
+
+func (x Side) Enum() *Side {
+	p := new(Side)
+	*p = x
+	return p
+}
+
+func (x Side) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Side) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_service_v1_order_service_messages_proto_enumTypes[2].Descriptor()
+}
+
+func (Side) Type() protoreflect.EnumType {
+	return &file_order_service_v1_order_service_messages_proto_enumTypes[2]
+}
+
+func (x Side) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Side.Descriptor instead.
+func (Side) EnumDescriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{2}
+}
+
+type TimeInForce int32
+
+const (
+	// good till cancelled
+	TimeInForce_GTC TimeInForce = 0
+	// immediate or cancel, the unfilled rest expires at once
+	TimeInForce_IOC TimeInForce = 1
+	// fill or kill, expires at once unless fully filled
+	TimeInForce_FOK TimeInForce = 2
+	// good till date, expires at expire_at
+	TimeInForce_GTD TimeInForce = 3
+)
+
+// Enum value maps for TimeInForce.
+var (
+	TimeInForce_name = map[int32]string{
+		0: "GTC",
+		1: "IOC",
+		2: "FOK",
+		3: "GTD",
+	}
+	TimeInForce_value = map[string]int32{
+		"GTC": 0,
+		"IOC": 1,
+		"FOK": 2,
+		"GTD": 3,
+	}
+)
+
+func (x TimeInForce) Enum() *TimeInForce {
+	p := new(TimeInForce)
+	*p = x
+	return p
+}
+
+func (x TimeInForce) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TimeInForce) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_service_v1_order_service_messages_proto_enumTypes[3].Descriptor()
+}
+
+func (TimeInForce) Type() protoreflect.EnumType {
+	return &file_order_service_v1_order_service_messages_proto_enumTypes[3]
+}
+
+func (x TimeInForce) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TimeInForce.Descriptor instead.
+func (TimeInForce) EnumDescriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{3}
+}
+
 type OrderType int32
 
 const (
 	OrderType_MARKET_ORDER OrderType = 0
 	OrderType_LIMIT_ORDER  OrderType = 1
+	// becomes a market order once the stop price is crossed
+	OrderType_STOP_MARKET OrderType = 2
+	// becomes a limit order at price once the stop price is crossed
+	OrderType_STOP_LIMIT OrderType = 3
 )
 
 // Enum value maps for OrderType.
@@ -83,10 +295,14 @@ var (
 	OrderType_name = map[int32]string{
 		0: "MARKET_ORDER",
 		1: "LIMIT_ORDER",
+		2: "STOP_MARKET",
+		3: "STOP_LIMIT",
 	}
 	OrderType_value = map[string]int32{
 		"MARKET_ORDER": 0,
 		"LIMIT_ORDER":  1,
+		"STOP_MARKET":  2,
+		"STOP_LIMIT":   3,
 	}
 )
 
@@ -101,11 +317,11 @@ func (x OrderType) String() string {
 }
 
 func (OrderType) Descriptor() protoreflect.EnumDescriptor {
-	return file_order_service_v1_order_service_messages_proto_enumTypes[1].Descriptor()
+	return file_order_service_v1_order_service_messages_proto_enumTypes[4].Descriptor()
 }
 
 func (OrderType) Type() protoreflect.EnumType {
-	return &file_order_service_v1_order_service_messages_proto_enumTypes[1]
+	return &file_order_service_v1_order_service_messages_proto_enumTypes[4]
 }
 
 func (x OrderType) Number() protoreflect.EnumNumber {
@@ -114,13 +330,115 @@ func (x OrderType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use OrderType.Descriptor instead.
 func (OrderType) EnumDescriptor() ([]byte, []int) {
-	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{1}
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{4}
+}
+
+type BatchMode int32
+
+const (
+	// nothing is applied when any item fails
+	BatchMode_ALL_OR_NOTHING BatchMode = 0
+	// every valid item is applied
+	BatchMode_BEST_EFFORT BatchMode = 1
+)
+
+// Enum value maps for BatchMode.
+var (
+	BatchMode_name = map[int32]string{
+		0: "ALL_OR_NOTHING",
+		1: "BEST_EFFORT",
+	}
+	BatchMode_value = map[string]int32{
+		"ALL_OR_NOTHING": 0,
+		"BEST_EFFORT":    1,
+	}
+)
+
+func (x BatchMode) Enum() *BatchMode {
+	p := new(BatchMode)
+	*p = x
+	return p
+}
+
+func (x BatchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_service_v1_order_service_messages_proto_enumTypes[5].Descriptor()
+}
+
+func (BatchMode) Type() protoreflect.EnumType {
+	return &file_order_service_v1_order_service_messages_proto_enumTypes[5]
+}
+
+func (x BatchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchMode.Descriptor instead.
+func (BatchMode) EnumDescriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{5}
+}
+
+type MarketMode int32
+
+const (
+	// orders are accepted and matched
+	MarketMode_TRADING MarketMode = 0
+	// no orders are accepted, matched or cancelled
+	MarketMode_HALTED MarketMode = 1
+	// only cancels are accepted
+	MarketMode_CANCEL_ONLY MarketMode = 2
+)
+
+// Enum value maps for MarketMode.
+var (
+	MarketMode_name = map[int32]string{
+		0: "TRADING",
+		1: "HALTED",
+		2: "CANCEL_ONLY",
+	}
+	MarketMode_value = map[string]int32{
+		"TRADING":     0,
+		"HALTED":      1,
+		"CANCEL_ONLY": 2,
+	}
+)
+
+func (x MarketMode) Enum() *MarketMode {
+	p := new(MarketMode)
+	*p = x
+	return p
+}
+
+func (x MarketMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MarketMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_order_service_v1_order_service_messages_proto_enumTypes[6].Descriptor()
+}
+
+func (MarketMode) Type() protoreflect.EnumType {
+	return &file_order_service_v1_order_service_messages_proto_enumTypes[6]
+}
+
+func (x MarketMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MarketMode.Descriptor instead.
+func (MarketMode) EnumDescriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{6}
 }
 
 type GetOrderStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId  string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// used when order_id is empty
+	ClientOrderId string `protobuf:"bytes,3,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -169,9 +487,23 @@ func (x *GetOrderStatusRequest) GetUserId() string {
 	return ""
 }
 
+func (x *GetOrderStatusRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
 type GetOrderStatusResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        Status                 `protobuf:"varint,1,opt,name=status,proto3,enum=order_service_v1.Status" json:"status,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Status       Status                 `protobuf:"varint,1,opt,name=status,proto3,enum=order_service_v1.Status" json:"status,omitempty"`
+	Version      int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	StatusReason StatusReason           `protobuf:"varint,3,opt,name=status_reason,json=statusReason,proto3,enum=order_service_v1.StatusReason" json:"status_reason,omitempty"`
+	StatusDetail string                 `protobuf:"bytes,4,opt,name=status_detail,json=statusDetail,proto3" json:"status_detail,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// set once the order reached a terminal status
+	TerminalAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=terminal_at,json=terminalAt,proto3" json:"terminal_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -213,14 +545,66 @@ func (x *GetOrderStatusResponse) GetStatus() Status {
 	return Status_CREATED
 }
 
+func (x *GetOrderStatusResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *GetOrderStatusResponse) GetStatusReason() StatusReason {
+	if x != nil {
+		return x.StatusReason
+	}
+	return StatusReason_NO_REASON
+}
+
+func (x *GetOrderStatusResponse) GetStatusDetail() string {
+	if x != nil {
+		return x.StatusDetail
+	}
+	return ""
+}
+
+func (x *GetOrderStatusResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *GetOrderStatusResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *GetOrderStatusResponse) GetTerminalAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TerminalAt
+	}
+	return nil
+}
+
 type CreateOrderRequest struct {
-	state         protoimpl.MessageState      `protogen:"open.v1"`
-	UserRole      spot_instrument_v1.UserRole `protobuf:"varint,1,opt,name=user_role,json=userRole,proto3,enum=common.UserRole" json:"user_role,omitempty"`
-	UserId        string                      `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	MarketId      string                      `protobuf:"bytes,3,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
-	OrderType     OrderType                   `protobuf:"varint,4,opt,name=order_type,json=orderType,proto3,enum=order_service_v1.OrderType" json:"order_type,omitempty"`
-	Price         float64                     `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	Quantity      float64                     `protobuf:"fixed64,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	state     protoimpl.MessageState      `protogen:"open.v1"`
+	UserRole  spot_instrument_v1.UserRole `protobuf:"varint,1,opt,name=user_role,json=userRole,proto3,enum=common.UserRole" json:"user_role,omitempty"`
+	UserId    string                      `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MarketId  string                      `protobuf:"bytes,3,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	OrderType OrderType                   `protobuf:"varint,4,opt,name=order_type,json=orderType,proto3,enum=order_service_v1.OrderType" json:"order_type,omitempty"`
+	Price     float64                     `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity  float64                     `protobuf:"fixed64,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// retries with the same key return the original order, the x-idempotency-key metadata works too
+	IdempotencyKey string `protobuf:"bytes,7,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// optional, unique per user among live orders
+	ClientOrderId string      `protobuf:"bytes,8,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	TimeInForce   TimeInForce `protobuf:"varint,9,opt,name=time_in_force,json=timeInForce,proto3,enum=order_service_v1.TimeInForce" json:"time_in_force,omitempty"`
+	// required for GTD
+	ExpireAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	Side     Side                   `protobuf:"varint,11,opt,name=side,proto3,enum=order_service_v1.Side" json:"side,omitempty"`
+	// required for STOP_MARKET and STOP_LIMIT
+	StopPrice     float64 `protobuf:"fixed64,12,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -297,10 +681,59 @@ func (x *CreateOrderRequest) GetQuantity() float64 {
 	return 0
 }
 
+func (x *CreateOrderRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetTimeInForce() TimeInForce {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return TimeInForce_GTC
+}
+
+func (x *CreateOrderRequest) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_BUY
+}
+
+func (x *CreateOrderRequest) GetStopPrice() float64 {
+	if x != nil {
+		return x.StopPrice
+	}
+	return 0
+}
+
 type CreateOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
 	Status        Status                 `protobuf:"varint,2,opt,name=status,proto3,enum=order_service_v1.Status" json:"status,omitempty"`
+	ClientOrderId string                 `protobuf:"bytes,3,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	StatusReason  StatusReason           `protobuf:"varint,4,opt,name=status_reason,json=statusReason,proto3,enum=order_service_v1.StatusReason" json:"status_reason,omitempty"`
+	StatusDetail  string                 `protobuf:"bytes,5,opt,name=status_detail,json=statusDetail,proto3" json:"status_detail,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version       int64                  `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	TerminalAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=terminal_at,json=terminalAt,proto3" json:"terminal_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -349,72 +782,104 @@ func (x *CreateOrderResponse) GetStatus() Status {
 	return Status_CREATED
 }
 
-type StreamOrderUpdatesRequest struct {
-	state         protoimpl.MessageState      `protogen:"open.v1"`
-	UserRole      spot_instrument_v1.UserRole `protobuf:"varint,1,opt,name=user_role,json=userRole,proto3,enum=common.UserRole" json:"user_role,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *CreateOrderResponse) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
 }
 
-func (x *StreamOrderUpdatesRequest) Reset() {
-	*x = StreamOrderUpdatesRequest{}
-	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[4]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *CreateOrderResponse) GetStatusReason() StatusReason {
+	if x != nil {
+		return x.StatusReason
+	}
+	return StatusReason_NO_REASON
 }
 
-func (x *StreamOrderUpdatesRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *CreateOrderResponse) GetStatusDetail() string {
+	if x != nil {
+		return x.StatusDetail
+	}
+	return ""
 }
 
-func (*StreamOrderUpdatesRequest) ProtoMessage() {}
+func (x *CreateOrderResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
 
-func (x *StreamOrderUpdatesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[4]
+func (x *CreateOrderResponse) GetVersion() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Version
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use StreamOrderUpdatesRequest.ProtoReflect.Descriptor instead.
-func (*StreamOrderUpdatesRequest) Descriptor() ([]byte, []int) {
-	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{4}
+func (x *CreateOrderResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
 }
 
-func (x *StreamOrderUpdatesRequest) GetUserRole() spot_instrument_v1.UserRole {
+func (x *CreateOrderResponse) GetTerminalAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.UserRole
+		return x.TerminalAt
 	}
-	return spot_instrument_v1.UserRole(0)
+	return nil
 }
 
-type OrderStatusUpdateResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        Status                 `protobuf:"varint,1,opt,name=status,proto3,enum=order_service_v1.Status" json:"status,omitempty"`
+type Order struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OrderId        string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MarketId       string                 `protobuf:"bytes,3,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	OrderType      OrderType              `protobuf:"varint,4,opt,name=order_type,json=orderType,proto3,enum=order_service_v1.OrderType" json:"order_type,omitempty"`
+	Price          float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity       float64                `protobuf:"fixed64,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Status         Status                 `protobuf:"varint,7,opt,name=status,proto3,enum=order_service_v1.Status" json:"status,omitempty"`
+	ClientOrderId  string                 `protobuf:"bytes,8,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	TimeInForce    TimeInForce            `protobuf:"varint,9,opt,name=time_in_force,json=timeInForce,proto3,enum=order_service_v1.TimeInForce" json:"time_in_force,omitempty"`
+	ExpireAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expire_at,json=expireAt,proto3" json:"expire_at,omitempty"`
+	Side           Side                   `protobuf:"varint,11,opt,name=side,proto3,enum=order_service_v1.Side" json:"side,omitempty"`
+	StopPrice      float64                `protobuf:"fixed64,12,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`
+	TriggeredAt    *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=triggered_at,json=triggeredAt,proto3" json:"triggered_at,omitempty"`
+	History        []*OrderEvent          `protobuf:"bytes,14,rep,name=history,proto3" json:"history,omitempty"`
+	FilledQuantity float64                `protobuf:"fixed64,15,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"`
+	// volume weighted price of the fills, 0 until the first fill
+	AvgFillPrice float64 `protobuf:"fixed64,16,opt,name=avg_fill_price,json=avgFillPrice,proto3" json:"avg_fill_price,omitempty"`
+	// sum of the fees of the fills, buys pay in the base asset and sells in the quote asset
+	FeeTotal float64 `protobuf:"fixed64,17,opt,name=fee_total,json=feeTotal,proto3" json:"fee_total,omitempty"`
+	FeeAsset string  `protobuf:"bytes,18,opt,name=fee_asset,json=feeAsset,proto3" json:"fee_asset,omitempty"`
+	// grows with every change of the order, mutating requests take it as expected_version
+	Version   int64                  `protobuf:"varint,19,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// set once the order reached a terminal status
+	TerminalAt    *timestamppb.Timestamp `protobuf:"bytes,22,opt,name=terminal_at,json=terminalAt,proto3" json:"terminal_at,omitempty"`
+	StatusReason  StatusReason           `protobuf:"varint,23,opt,name=status_reason,json=statusReason,proto3,enum=order_service_v1.StatusReason" json:"status_reason,omitempty"`
+	StatusDetail  string                 `protobuf:"bytes,24,opt,name=status_detail,json=statusDetail,proto3" json:"status_detail,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderStatusUpdateResponse) Reset() {
-	*x = OrderStatusUpdateResponse{}
-	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[5]
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderStatusUpdateResponse) String() string {
+func (x *Order) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderStatusUpdateResponse) ProtoMessage() {}
+func (*Order) ProtoMessage() {}
 
-func (x *OrderStatusUpdateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[5]
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -425,41 +890,3734 @@ func (x *OrderStatusUpdateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderStatusUpdateResponse.ProtoReflect.Descriptor instead.
-func (*OrderStatusUpdateResponse) Descriptor() ([]byte, []int) {
-	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Order) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Order) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Order) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *Order) GetOrderType() OrderType {
+	if x != nil {
+		return x.OrderType
+	}
+	return OrderType_MARKET_ORDER
+}
+
+func (x *Order) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Order) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Order) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_CREATED
+}
+
+func (x *Order) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *Order) GetTimeInForce() TimeInForce {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return TimeInForce_GTC
+}
+
+func (x *Order) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
+func (x *Order) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_BUY
+}
+
+func (x *Order) GetStopPrice() float64 {
+	if x != nil {
+		return x.StopPrice
+	}
+	return 0
+}
+
+func (x *Order) GetTriggeredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TriggeredAt
+	}
+	return nil
+}
+
+func (x *Order) GetHistory() []*OrderEvent {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *Order) GetFilledQuantity() float64 {
+	if x != nil {
+		return x.FilledQuantity
+	}
+	return 0
+}
+
+func (x *Order) GetAvgFillPrice() float64 {
+	if x != nil {
+		return x.AvgFillPrice
+	}
+	return 0
+}
+
+func (x *Order) GetFeeTotal() float64 {
+	if x != nil {
+		return x.FeeTotal
+	}
+	return 0
+}
+
+func (x *Order) GetFeeAsset() string {
+	if x != nil {
+		return x.FeeAsset
+	}
+	return ""
+}
+
+func (x *Order) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetTerminalAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TerminalAt
+	}
+	return nil
+}
+
+func (x *Order) GetStatusReason() StatusReason {
+	if x != nil {
+		return x.StatusReason
+	}
+	return StatusReason_NO_REASON
+}
+
+func (x *Order) GetStatusDetail() string {
+	if x != nil {
+		return x.StatusDetail
+	}
+	return ""
+}
+
+type OrderEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=at,proto3" json:"at,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Detail        string                 `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderEvent) Reset() {
+	*x = OrderEvent{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderEvent) ProtoMessage() {}
+
+func (x *OrderEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderEvent.ProtoReflect.Descriptor instead.
+func (*OrderEvent) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *OrderEvent) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *OrderEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *OrderEvent) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+type GetOrderRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	UserId  string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrderId string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// used when order_id is empty
+	ClientOrderId string `protobuf:"bytes,3,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetOrderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *GetOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+type GetOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderResponse) Reset() {
+	*x = GetOrderResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderResponse) ProtoMessage() {}
+
+func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type CancelOrderRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	UserId  string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrderId string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// used when order_id is empty
+	ClientOrderId string `protobuf:"bytes,3,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	// when set the order is only cancelled at this version, otherwise ABORTED
+	ExpectedVersion int64 `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CancelOrderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type CancelOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        Status                 `protobuf:"varint,2,opt,name=status,proto3,enum=order_service_v1.Status" json:"status,omitempty"`
+	Version       int64                  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	StatusReason  StatusReason           `protobuf:"varint,4,opt,name=status_reason,json=statusReason,proto3,enum=order_service_v1.StatusReason" json:"status_reason,omitempty"`
+	StatusDetail  string                 `protobuf:"bytes,5,opt,name=status_detail,json=statusDetail,proto3" json:"status_detail,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	TerminalAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=terminal_at,json=terminalAt,proto3" json:"terminal_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResponse) ProtoMessage() {}
+
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CancelOrderResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CancelOrderResponse) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_CREATED
+}
+
+func (x *CancelOrderResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *CancelOrderResponse) GetStatusReason() StatusReason {
+	if x != nil {
+		return x.StatusReason
+	}
+	return StatusReason_NO_REASON
+}
+
+func (x *CancelOrderResponse) GetStatusDetail() string {
+	if x != nil {
+		return x.StatusDetail
+	}
+	return ""
+}
+
+func (x *CancelOrderResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *CancelOrderResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CancelOrderResponse) GetTerminalAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TerminalAt
+	}
+	return nil
+}
+
+type StreamOrderUpdatesRequest struct {
+	state    protoimpl.MessageState      `protogen:"open.v1"`
+	UserRole spot_instrument_v1.UserRole `protobuf:"varint,1,opt,name=user_role,json=userRole,proto3,enum=common.UserRole" json:"user_role,omitempty"`
+	// only updates of this user when set
+	UserId string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// cancel every open order of user_id when the stream drops without
+	// CloseOrderUpdatesSession and the user does not reconnect within the grace period
+	CancelOnDisconnect bool `protobuf:"varint,3,opt,name=cancel_on_disconnect,json=cancelOnDisconnect,proto3" json:"cancel_on_disconnect,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *StreamOrderUpdatesRequest) Reset() {
+	*x = StreamOrderUpdatesRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamOrderUpdatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamOrderUpdatesRequest) ProtoMessage() {}
+
+func (x *StreamOrderUpdatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamOrderUpdatesRequest.ProtoReflect.Descriptor instead.
+func (*StreamOrderUpdatesRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *StreamOrderUpdatesRequest) GetUserRole() spot_instrument_v1.UserRole {
+	if x != nil {
+		return x.UserRole
+	}
+	return spot_instrument_v1.UserRole(0)
+}
+
+func (x *StreamOrderUpdatesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StreamOrderUpdatesRequest) GetCancelOnDisconnect() bool {
+	if x != nil {
+		return x.CancelOnDisconnect
+	}
+	return false
+}
+
+type OrderStatusUpdateResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Status Status                 `protobuf:"varint,1,opt,name=status,proto3,enum=order_service_v1.Status" json:"status,omitempty"`
+	Order  *Order                 `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	// only set on the first message of a cancel_on_disconnect stream
+	SessionId     string `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderStatusUpdateResponse) Reset() {
+	*x = OrderStatusUpdateResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStatusUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusUpdateResponse) ProtoMessage() {}
+
+func (x *OrderStatusUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusUpdateResponse.ProtoReflect.Descriptor instead.
+func (*OrderStatusUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *OrderStatusUpdateResponse) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_CREATED
+}
+
+func (x *OrderStatusUpdateResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *OrderStatusUpdateResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type CloseOrderUpdatesSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseOrderUpdatesSessionRequest) Reset() {
+	*x = CloseOrderUpdatesSessionRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseOrderUpdatesSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseOrderUpdatesSessionRequest) ProtoMessage() {}
+
+func (x *CloseOrderUpdatesSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseOrderUpdatesSessionRequest.ProtoReflect.Descriptor instead.
+func (*CloseOrderUpdatesSessionRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CloseOrderUpdatesSessionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CloseOrderUpdatesSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type CloseOrderUpdatesSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseOrderUpdatesSessionResponse) Reset() {
+	*x = CloseOrderUpdatesSessionResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseOrderUpdatesSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseOrderUpdatesSessionResponse) ProtoMessage() {}
+
+func (x *CloseOrderUpdatesSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseOrderUpdatesSessionResponse.ProtoReflect.Descriptor instead.
+func (*CloseOrderUpdatesSessionResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{13}
+}
+
+type CancelAllAfterRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// every open order of user_id is cancelled unless this is called again within
+	// timeout_ms, 0 disarms the switch
+	TimeoutMs     int64 `protobuf:"varint,2,opt,name=timeout_ms,json=timeoutMs,proto3" json:"timeout_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelAllAfterRequest) Reset() {
+	*x = CancelAllAfterRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelAllAfterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAllAfterRequest) ProtoMessage() {}
+
+func (x *CancelAllAfterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAllAfterRequest.ProtoReflect.Descriptor instead.
+func (*CancelAllAfterRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CancelAllAfterRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CancelAllAfterRequest) GetTimeoutMs() int64 {
+	if x != nil {
+		return x.TimeoutMs
+	}
+	return 0
+}
+
+type CancelAllAfterResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// unset when the switch was disarmed
+	CancelAt      *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=cancel_at,json=cancelAt,proto3" json:"cancel_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelAllAfterResponse) Reset() {
+	*x = CancelAllAfterResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelAllAfterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAllAfterResponse) ProtoMessage() {}
+
+func (x *CancelAllAfterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAllAfterResponse.ProtoReflect.Descriptor instead.
+func (*CancelAllAfterResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CancelAllAfterResponse) GetCancelAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CancelAt
+	}
+	return nil
+}
+
+type UpdateOrderStatusRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status  Status                 `protobuf:"varint,2,opt,name=status,proto3,enum=order_service_v1.Status" json:"status,omitempty"`
+	// when set the status is only updated at this version, otherwise ABORTED
+	ExpectedVersion int64 `protobuf:"varint,3,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateOrderStatusRequest) Reset() {
+	*x = UpdateOrderStatusRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderStatusRequest) ProtoMessage() {}
+
+func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateOrderStatusRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *UpdateOrderStatusRequest) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_CREATED
+}
+
+func (x *UpdateOrderStatusRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type UpdateOrderStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        Status                 `protobuf:"varint,1,opt,name=status,proto3,enum=order_service_v1.Status" json:"status,omitempty"`
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	StatusReason  StatusReason           `protobuf:"varint,3,opt,name=status_reason,json=statusReason,proto3,enum=order_service_v1.StatusReason" json:"status_reason,omitempty"`
+	StatusDetail  string                 `protobuf:"bytes,4,opt,name=status_detail,json=statusDetail,proto3" json:"status_detail,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	TerminalAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=terminal_at,json=terminalAt,proto3" json:"terminal_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderStatusResponse) Reset() {
+	*x = UpdateOrderStatusResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderStatusResponse) ProtoMessage() {}
+
+func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrderStatusResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateOrderStatusResponse) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_CREATED
+}
+
+func (x *UpdateOrderStatusResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UpdateOrderStatusResponse) GetStatusReason() StatusReason {
+	if x != nil {
+		return x.StatusReason
+	}
+	return StatusReason_NO_REASON
+}
+
+func (x *UpdateOrderStatusResponse) GetStatusDetail() string {
+	if x != nil {
+		return x.StatusDetail
+	}
+	return ""
+}
+
+func (x *UpdateOrderStatusResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *UpdateOrderStatusResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *UpdateOrderStatusResponse) GetTerminalAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TerminalAt
+	}
+	return nil
+}
+
+type RefreshMarketCatalogRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// empty refreshes every role
+	UserRoles []spot_instrument_v1.UserRole `protobuf:"varint,1,rep,packed,name=user_roles,json=userRoles,proto3,enum=common.UserRole" json:"user_roles,omitempty"`
+	// role of the caller, must be USER_ROLE_ADMIN
+	UserRole      spot_instrument_v1.UserRole `protobuf:"varint,2,opt,name=user_role,json=userRole,proto3,enum=common.UserRole" json:"user_role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshMarketCatalogRequest) Reset() {
+	*x = RefreshMarketCatalogRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshMarketCatalogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshMarketCatalogRequest) ProtoMessage() {}
+
+func (x *RefreshMarketCatalogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshMarketCatalogRequest.ProtoReflect.Descriptor instead.
+func (*RefreshMarketCatalogRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RefreshMarketCatalogRequest) GetUserRoles() []spot_instrument_v1.UserRole {
+	if x != nil {
+		return x.UserRoles
+	}
+	return nil
+}
+
+func (x *RefreshMarketCatalogRequest) GetUserRole() spot_instrument_v1.UserRole {
+	if x != nil {
+		return x.UserRole
+	}
+	return spot_instrument_v1.UserRole(0)
+}
+
+type MarketCatalogState struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	UserRole      spot_instrument_v1.UserRole `protobuf:"varint,1,opt,name=user_role,json=userRole,proto3,enum=common.UserRole" json:"user_role,omitempty"`
+	Version       uint64                      `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	MarketCount   int32                       `protobuf:"varint,3,opt,name=market_count,json=marketCount,proto3" json:"market_count,omitempty"`
+	SyncedAt      *timestamppb.Timestamp      `protobuf:"bytes,4,opt,name=synced_at,json=syncedAt,proto3" json:"synced_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarketCatalogState) Reset() {
+	*x = MarketCatalogState{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketCatalogState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketCatalogState) ProtoMessage() {}
+
+func (x *MarketCatalogState) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketCatalogState.ProtoReflect.Descriptor instead.
+func (*MarketCatalogState) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *MarketCatalogState) GetUserRole() spot_instrument_v1.UserRole {
+	if x != nil {
+		return x.UserRole
+	}
+	return spot_instrument_v1.UserRole(0)
+}
+
+func (x *MarketCatalogState) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *MarketCatalogState) GetMarketCount() int32 {
+	if x != nil {
+		return x.MarketCount
+	}
+	return 0
+}
+
+func (x *MarketCatalogState) GetSyncedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SyncedAt
+	}
+	return nil
+}
+
+type RefreshMarketCatalogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Catalogs      []*MarketCatalogState  `protobuf:"bytes,1,rep,name=catalogs,proto3" json:"catalogs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshMarketCatalogResponse) Reset() {
+	*x = RefreshMarketCatalogResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshMarketCatalogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshMarketCatalogResponse) ProtoMessage() {}
+
+func (x *RefreshMarketCatalogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshMarketCatalogResponse.ProtoReflect.Descriptor instead.
+func (*RefreshMarketCatalogResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RefreshMarketCatalogResponse) GetCatalogs() []*MarketCatalogState {
+	if x != nil {
+		return x.Catalogs
+	}
+	return nil
+}
+
+type ItemError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// google.golang.org/grpc/codes value
+	Code          int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ItemError) Reset() {
+	*x = ItemError{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ItemError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ItemError) ProtoMessage() {}
+
+func (x *ItemError) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ItemError.ProtoReflect.Descriptor instead.
+func (*ItemError) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ItemError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *ItemError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ItemError) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CreateOrdersRequest struct {
+	state    protoimpl.MessageState      `protogen:"open.v1"`
+	UserRole spot_instrument_v1.UserRole `protobuf:"varint,1,opt,name=user_role,json=userRole,proto3,enum=common.UserRole" json:"user_role,omitempty"`
+	UserId   string                      `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// user_role and user_id of the items are taken from the batch, items must not set
+	// idempotency_key
+	Orders        []*CreateOrderRequest `protobuf:"bytes,3,rep,name=orders,proto3" json:"orders,omitempty"`
+	Mode          BatchMode             `protobuf:"varint,4,opt,name=mode,proto3,enum=order_service_v1.BatchMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrdersRequest) Reset() {
+	*x = CreateOrdersRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrdersRequest) ProtoMessage() {}
+
+func (x *CreateOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrdersRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateOrdersRequest) GetUserRole() spot_instrument_v1.UserRole {
+	if x != nil {
+		return x.UserRole
+	}
+	return spot_instrument_v1.UserRole(0)
+}
+
+func (x *CreateOrdersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateOrdersRequest) GetOrders() []*CreateOrderRequest {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *CreateOrdersRequest) GetMode() BatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return BatchMode_ALL_OR_NOTHING
+}
+
+type CreateOrderResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        Status                 `protobuf:"varint,3,opt,name=status,proto3,enum=order_service_v1.Status" json:"status,omitempty"`
+	ClientOrderId string                 `protobuf:"bytes,4,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	Error         *ItemError             `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	Version       int64                  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	StatusReason  StatusReason           `protobuf:"varint,7,opt,name=status_reason,json=statusReason,proto3,enum=order_service_v1.StatusReason" json:"status_reason,omitempty"`
+	StatusDetail  string                 `protobuf:"bytes,8,opt,name=status_detail,json=statusDetail,proto3" json:"status_detail,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	TerminalAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=terminal_at,json=terminalAt,proto3" json:"terminal_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderResult) Reset() {
+	*x = CreateOrderResult{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderResult) ProtoMessage() {}
+
+func (x *CreateOrderResult) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderResult.ProtoReflect.Descriptor instead.
+func (*CreateOrderResult) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateOrderResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *CreateOrderResult) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CreateOrderResult) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_CREATED
+}
+
+func (x *CreateOrderResult) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *CreateOrderResult) GetError() *ItemError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *CreateOrderResult) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *CreateOrderResult) GetStatusReason() StatusReason {
+	if x != nil {
+		return x.StatusReason
+	}
+	return StatusReason_NO_REASON
+}
+
+func (x *CreateOrderResult) GetStatusDetail() string {
+	if x != nil {
+		return x.StatusDetail
+	}
+	return ""
+}
+
+func (x *CreateOrderResult) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *CreateOrderResult) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CreateOrderResult) GetTerminalAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TerminalAt
+	}
+	return nil
+}
+
+type CreateOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*CreateOrderResult   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrdersResponse) Reset() {
+	*x = CreateOrdersResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrdersResponse) ProtoMessage() {}
+
+func (x *CreateOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrdersResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateOrdersResponse) GetResults() []*CreateOrderResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type CancelOrdersRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// user_id of the items is taken from the batch
+	Orders        []*CancelOrderRequest `protobuf:"bytes,2,rep,name=orders,proto3" json:"orders,omitempty"`
+	Mode          BatchMode             `protobuf:"varint,3,opt,name=mode,proto3,enum=order_service_v1.BatchMode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrdersRequest) Reset() {
+	*x = CancelOrdersRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrdersRequest) ProtoMessage() {}
+
+func (x *CancelOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrdersRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CancelOrdersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CancelOrdersRequest) GetOrders() []*CancelOrderRequest {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *CancelOrdersRequest) GetMode() BatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return BatchMode_ALL_OR_NOTHING
+}
+
+type CancelOrderResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        Status                 `protobuf:"varint,3,opt,name=status,proto3,enum=order_service_v1.Status" json:"status,omitempty"`
+	Error         *ItemError             `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Version       int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	StatusReason  StatusReason           `protobuf:"varint,6,opt,name=status_reason,json=statusReason,proto3,enum=order_service_v1.StatusReason" json:"status_reason,omitempty"`
+	StatusDetail  string                 `protobuf:"bytes,7,opt,name=status_detail,json=statusDetail,proto3" json:"status_detail,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	TerminalAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=terminal_at,json=terminalAt,proto3" json:"terminal_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderResult) Reset() {
+	*x = CancelOrderResult{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResult) ProtoMessage() {}
+
+func (x *CancelOrderResult) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResult.ProtoReflect.Descriptor instead.
+func (*CancelOrderResult) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CancelOrderResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *CancelOrderResult) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CancelOrderResult) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_CREATED
+}
+
+func (x *CancelOrderResult) GetError() *ItemError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *CancelOrderResult) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *CancelOrderResult) GetStatusReason() StatusReason {
+	if x != nil {
+		return x.StatusReason
+	}
+	return StatusReason_NO_REASON
+}
+
+func (x *CancelOrderResult) GetStatusDetail() string {
+	if x != nil {
+		return x.StatusDetail
+	}
+	return ""
+}
+
+func (x *CancelOrderResult) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *CancelOrderResult) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *CancelOrderResult) GetTerminalAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TerminalAt
+	}
+	return nil
+}
+
+type CancelOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*CancelOrderResult   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrdersResponse) Reset() {
+	*x = CancelOrdersResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrdersResponse) ProtoMessage() {}
+
+func (x *CancelOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrdersResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CancelOrdersResponse) GetResults() []*CancelOrderResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type SetReferencePriceRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MarketId string                 `protobuf:"bytes,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Price    float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	// must be USER_ROLE_ADMIN
+	UserRole      spot_instrument_v1.UserRole `protobuf:"varint,3,opt,name=user_role,json=userRole,proto3,enum=common.UserRole" json:"user_role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetReferencePriceRequest) Reset() {
+	*x = SetReferencePriceRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReferencePriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReferencePriceRequest) ProtoMessage() {}
+
+func (x *SetReferencePriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReferencePriceRequest.ProtoReflect.Descriptor instead.
+func (*SetReferencePriceRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SetReferencePriceRequest) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *SetReferencePriceRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *SetReferencePriceRequest) GetUserRole() spot_instrument_v1.UserRole {
+	if x != nil {
+		return x.UserRole
+	}
+	return spot_instrument_v1.UserRole(0)
+}
+
+type SetReferencePriceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// stop orders released by this price
+	TriggeredOrderIds []string `protobuf:"bytes,1,rep,name=triggered_order_ids,json=triggeredOrderIds,proto3" json:"triggered_order_ids,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SetReferencePriceResponse) Reset() {
+	*x = SetReferencePriceResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetReferencePriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetReferencePriceResponse) ProtoMessage() {}
+
+func (x *SetReferencePriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetReferencePriceResponse.ProtoReflect.Descriptor instead.
+func (*SetReferencePriceResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SetReferencePriceResponse) GetTriggeredOrderIds() []string {
+	if x != nil {
+		return x.TriggeredOrderIds
+	}
+	return nil
+}
+
+type PriceLevel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Price         float64                `protobuf:"fixed64,1,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	OrderCount    int32                  `protobuf:"varint,3,opt,name=order_count,json=orderCount,proto3" json:"order_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceLevel) Reset() {
+	*x = PriceLevel{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceLevel) ProtoMessage() {}
+
+func (x *PriceLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceLevel.ProtoReflect.Descriptor instead.
+func (*PriceLevel) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PriceLevel) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PriceLevel) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *PriceLevel) GetOrderCount() int32 {
+	if x != nil {
+		return x.OrderCount
+	}
+	return 0
+}
+
+type GetOrderBookRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MarketId string                 `protobuf:"bytes,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	// levels per side, every level when 0
+	Depth         int32 `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderBookRequest) Reset() {
+	*x = GetOrderBookRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderBookRequest) ProtoMessage() {}
+
+func (x *GetOrderBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderBookRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderBookRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetOrderBookRequest) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *GetOrderBookRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+type GetOrderBookResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MarketId string                 `protobuf:"bytes,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Sequence uint64                 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// best price first
+	Bids          []*PriceLevel `protobuf:"bytes,3,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks          []*PriceLevel `protobuf:"bytes,4,rep,name=asks,proto3" json:"asks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderBookResponse) Reset() {
+	*x = GetOrderBookResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderBookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderBookResponse) ProtoMessage() {}
+
+func (x *GetOrderBookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderBookResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderBookResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetOrderBookResponse) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *GetOrderBookResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *GetOrderBookResponse) GetBids() []*PriceLevel {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *GetOrderBookResponse) GetAsks() []*PriceLevel {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+type StreamOrderBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MarketId      string                 `protobuf:"bytes,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamOrderBookRequest) Reset() {
+	*x = StreamOrderBookRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamOrderBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamOrderBookRequest) ProtoMessage() {}
+
+func (x *StreamOrderBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamOrderBookRequest.ProtoReflect.Descriptor instead.
+func (*StreamOrderBookRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *StreamOrderBookRequest) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+type LevelUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Side  Side                   `protobuf:"varint,1,opt,name=side,proto3,enum=order_service_v1.Side" json:"side,omitempty"`
+	// quantity 0 removes the level
+	Level         *PriceLevel `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LevelUpdate) Reset() {
+	*x = LevelUpdate{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LevelUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LevelUpdate) ProtoMessage() {}
+
+func (x *LevelUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LevelUpdate.ProtoReflect.Descriptor instead.
+func (*LevelUpdate) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *LevelUpdate) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_BUY
+}
+
+func (x *LevelUpdate) GetLevel() *PriceLevel {
+	if x != nil {
+		return x.Level
+	}
+	return nil
+}
+
+type OrderBookUpdate struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MarketId string                 `protobuf:"bytes,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	// snapshots carry the sequence they reflect, diffs apply on top of sequence - 1
+	Sequence      uint64         `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Snapshot      bool           `protobuf:"varint,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	Bids          []*PriceLevel  `protobuf:"bytes,4,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks          []*PriceLevel  `protobuf:"bytes,5,rep,name=asks,proto3" json:"asks,omitempty"`
+	Updates       []*LevelUpdate `protobuf:"bytes,6,rep,name=updates,proto3" json:"updates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderBookUpdate) Reset() {
+	*x = OrderBookUpdate{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderBookUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBookUpdate) ProtoMessage() {}
+
+func (x *OrderBookUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBookUpdate.ProtoReflect.Descriptor instead.
+func (*OrderBookUpdate) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *OrderBookUpdate) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *OrderBookUpdate) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *OrderBookUpdate) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+func (x *OrderBookUpdate) GetBids() []*PriceLevel {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *OrderBookUpdate) GetAsks() []*PriceLevel {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+func (x *OrderBookUpdate) GetUpdates() []*LevelUpdate {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+type Trade struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	TradeId     string                 `protobuf:"bytes,1,opt,name=trade_id,json=tradeId,proto3" json:"trade_id,omitempty"`
+	MarketId    string                 `protobuf:"bytes,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	BuyOrderId  string                 `protobuf:"bytes,3,opt,name=buy_order_id,json=buyOrderId,proto3" json:"buy_order_id,omitempty"`
+	SellOrderId string                 `protobuf:"bytes,4,opt,name=sell_order_id,json=sellOrderId,proto3" json:"sell_order_id,omitempty"`
+	Price       float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity    float64                `protobuf:"fixed64,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ExecutedAt  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"`
+	// side of the taker order, the other order was the maker resting in the book
+	TakerSide     Side    `protobuf:"varint,8,opt,name=taker_side,json=takerSide,proto3,enum=order_service_v1.Side" json:"taker_side,omitempty"`
+	BuyFee        float64 `protobuf:"fixed64,9,opt,name=buy_fee,json=buyFee,proto3" json:"buy_fee,omitempty"`
+	BuyFeeAsset   string  `protobuf:"bytes,10,opt,name=buy_fee_asset,json=buyFeeAsset,proto3" json:"buy_fee_asset,omitempty"`
+	SellFee       float64 `protobuf:"fixed64,11,opt,name=sell_fee,json=sellFee,proto3" json:"sell_fee,omitempty"`
+	SellFeeAsset  string  `protobuf:"bytes,12,opt,name=sell_fee_asset,json=sellFeeAsset,proto3" json:"sell_fee_asset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Trade) Reset() {
+	*x = Trade{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trade) ProtoMessage() {}
+
+func (x *Trade) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Trade) GetTradeId() string {
+	if x != nil {
+		return x.TradeId
+	}
+	return ""
+}
+
+func (x *Trade) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *Trade) GetBuyOrderId() string {
+	if x != nil {
+		return x.BuyOrderId
+	}
+	return ""
+}
+
+func (x *Trade) GetSellOrderId() string {
+	if x != nil {
+		return x.SellOrderId
+	}
+	return ""
+}
+
+func (x *Trade) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Trade) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Trade) GetExecutedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExecutedAt
+	}
+	return nil
+}
+
+func (x *Trade) GetTakerSide() Side {
+	if x != nil {
+		return x.TakerSide
+	}
+	return Side_BUY
+}
+
+func (x *Trade) GetBuyFee() float64 {
+	if x != nil {
+		return x.BuyFee
+	}
+	return 0
+}
+
+func (x *Trade) GetBuyFeeAsset() string {
+	if x != nil {
+		return x.BuyFeeAsset
+	}
+	return ""
+}
+
+func (x *Trade) GetSellFee() float64 {
+	if x != nil {
+		return x.SellFee
+	}
+	return 0
+}
+
+func (x *Trade) GetSellFeeAsset() string {
+	if x != nil {
+		return x.SellFeeAsset
+	}
+	return ""
+}
+
+type ListTradesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// at least one of user_id and market_id is required
+	UserId   string `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MarketId string `protobuf:"bytes,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	// 100 when 0, at most 1000
+	PageSize      int32  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTradesRequest) Reset() {
+	*x = ListTradesRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTradesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTradesRequest) ProtoMessage() {}
+
+func (x *ListTradesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTradesRequest.ProtoReflect.Descriptor instead.
+func (*ListTradesRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListTradesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListTradesRequest) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *ListTradesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTradesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListTradesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// oldest first
+	Trades []*Trade `protobuf:"bytes,1,rep,name=trades,proto3" json:"trades,omitempty"`
+	// empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTradesResponse) Reset() {
+	*x = ListTradesResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTradesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTradesResponse) ProtoMessage() {}
+
+func (x *ListTradesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTradesResponse.ProtoReflect.Descriptor instead.
+func (*ListTradesResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListTradesResponse) GetTrades() []*Trade {
+	if x != nil {
+		return x.Trades
+	}
+	return nil
+}
+
+func (x *ListTradesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type Balance struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Asset     string                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	Available float64                `protobuf:"fixed64,2,opt,name=available,proto3" json:"available,omitempty"`
+	// held by open orders
+	Reserved      float64 `protobuf:"fixed64,3,opt,name=reserved,proto3" json:"reserved,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Balance) Reset() {
+	*x = Balance{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Balance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Balance) ProtoMessage() {}
+
+func (x *Balance) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Balance.ProtoReflect.Descriptor instead.
+func (*Balance) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Balance) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *Balance) GetAvailable() float64 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *Balance) GetReserved() float64 {
+	if x != nil {
+		return x.Reserved
+	}
+	return 0
+}
+
+type CreditAccountRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// must be USER_ROLE_ADMIN
+	UserRole spot_instrument_v1.UserRole `protobuf:"varint,1,opt,name=user_role,json=userRole,proto3,enum=common.UserRole" json:"user_role,omitempty"`
+	UserId   string                      `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Asset    string                      `protobuf:"bytes,3,opt,name=asset,proto3" json:"asset,omitempty"`
+	Amount   float64                     `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	// free text kept with the ledger entry
+	Reference     string `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreditAccountRequest) Reset() {
+	*x = CreditAccountRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreditAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreditAccountRequest) ProtoMessage() {}
+
+func (x *CreditAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreditAccountRequest.ProtoReflect.Descriptor instead.
+func (*CreditAccountRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CreditAccountRequest) GetUserRole() spot_instrument_v1.UserRole {
+	if x != nil {
+		return x.UserRole
+	}
+	return spot_instrument_v1.UserRole(0)
+}
+
+func (x *CreditAccountRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreditAccountRequest) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *CreditAccountRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreditAccountRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+type CreditAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balance       *Balance               `protobuf:"bytes,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreditAccountResponse) Reset() {
+	*x = CreditAccountResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreditAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreditAccountResponse) ProtoMessage() {}
+
+func (x *CreditAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreditAccountResponse.ProtoReflect.Descriptor instead.
+func (*CreditAccountResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CreditAccountResponse) GetBalance() *Balance {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+type DebitAccountRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// must be USER_ROLE_ADMIN
+	UserRole spot_instrument_v1.UserRole `protobuf:"varint,1,opt,name=user_role,json=userRole,proto3,enum=common.UserRole" json:"user_role,omitempty"`
+	UserId   string                      `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Asset    string                      `protobuf:"bytes,3,opt,name=asset,proto3" json:"asset,omitempty"`
+	Amount   float64                     `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	// free text kept with the ledger entry
+	Reference     string `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DebitAccountRequest) Reset() {
+	*x = DebitAccountRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebitAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebitAccountRequest) ProtoMessage() {}
+
+func (x *DebitAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebitAccountRequest.ProtoReflect.Descriptor instead.
+func (*DebitAccountRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DebitAccountRequest) GetUserRole() spot_instrument_v1.UserRole {
+	if x != nil {
+		return x.UserRole
+	}
+	return spot_instrument_v1.UserRole(0)
+}
+
+func (x *DebitAccountRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DebitAccountRequest) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *DebitAccountRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *DebitAccountRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+type DebitAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balance       *Balance               `protobuf:"bytes,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DebitAccountResponse) Reset() {
+	*x = DebitAccountResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebitAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebitAccountResponse) ProtoMessage() {}
+
+func (x *DebitAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebitAccountResponse.ProtoReflect.Descriptor instead.
+func (*DebitAccountResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DebitAccountResponse) GetBalance() *Balance {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+type GetBalancesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalancesRequest) Reset() {
+	*x = GetBalancesRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalancesRequest) ProtoMessage() {}
+
+func (x *GetBalancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalancesRequest.ProtoReflect.Descriptor instead.
+func (*GetBalancesRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetBalancesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetBalancesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balances      []*Balance             `protobuf:"bytes,1,rep,name=balances,proto3" json:"balances,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalancesResponse) Reset() {
+	*x = GetBalancesResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalancesResponse) ProtoMessage() {}
+
+func (x *GetBalancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalancesResponse.ProtoReflect.Descriptor instead.
+func (*GetBalancesResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetBalancesResponse) GetBalances() []*Balance {
+	if x != nil {
+		return x.Balances
+	}
+	return nil
+}
+
+type Position struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MarketId string                 `protobuf:"bytes,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	// positive for long, negative for short
+	NetQuantity   float64 `protobuf:"fixed64,3,opt,name=net_quantity,json=netQuantity,proto3" json:"net_quantity,omitempty"`
+	AvgEntryPrice float64 `protobuf:"fixed64,4,opt,name=avg_entry_price,json=avgEntryPrice,proto3" json:"avg_entry_price,omitempty"`
+	// in the quote asset of the market
+	RealizedPnl   float64                `protobuf:"fixed64,5,opt,name=realized_pnl,json=realizedPnl,proto3" json:"realized_pnl,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *Position) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Position) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *Position) GetNetQuantity() float64 {
+	if x != nil {
+		return x.NetQuantity
+	}
+	return 0
+}
+
+func (x *Position) GetAvgEntryPrice() float64 {
+	if x != nil {
+		return x.AvgEntryPrice
+	}
+	return 0
+}
+
+func (x *Position) GetRealizedPnl() float64 {
+	if x != nil {
+		return x.RealizedPnl
+	}
+	return 0
+}
+
+func (x *Position) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetPositionsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// every market when empty
+	MarketId      string `protobuf:"bytes,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPositionsRequest) Reset() {
+	*x = GetPositionsRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPositionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPositionsRequest) ProtoMessage() {}
+
+func (x *GetPositionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPositionsRequest.ProtoReflect.Descriptor instead.
+func (*GetPositionsRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetPositionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetPositionsRequest) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+type GetPositionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Positions     []*Position            `protobuf:"bytes,1,rep,name=positions,proto3" json:"positions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPositionsResponse) Reset() {
+	*x = GetPositionsResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPositionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPositionsResponse) ProtoMessage() {}
+
+func (x *GetPositionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPositionsResponse.ProtoReflect.Descriptor instead.
+func (*GetPositionsResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetPositionsResponse) GetPositions() []*Position {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+type StreamPositionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamPositionsRequest) Reset() {
+	*x = StreamPositionsRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamPositionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPositionsRequest) ProtoMessage() {}
+
+func (x *StreamPositionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPositionsRequest.ProtoReflect.Descriptor instead.
+func (*StreamPositionsRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *StreamPositionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type PositionUpdate struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Position *Position              `protobuf:"bytes,1,opt,name=position,proto3" json:"position,omitempty"`
+	// set on the current positions sent when the stream starts
+	Snapshot      bool `protobuf:"varint,2,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PositionUpdate) Reset() {
+	*x = PositionUpdate{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PositionUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PositionUpdate) ProtoMessage() {}
+
+func (x *PositionUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PositionUpdate.ProtoReflect.Descriptor instead.
+func (*PositionUpdate) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *PositionUpdate) GetPosition() *Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *PositionUpdate) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+type MarketState struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MarketId      string                 `protobuf:"bytes,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Mode          MarketMode             `protobuf:"varint,2,opt,name=mode,proto3,enum=order_service_v1.MarketMode" json:"mode,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarketState) Reset() {
+	*x = MarketState{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarketState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarketState) ProtoMessage() {}
+
+func (x *MarketState) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarketState.ProtoReflect.Descriptor instead.
+func (*MarketState) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *MarketState) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *MarketState) GetMode() MarketMode {
+	if x != nil {
+		return x.Mode
+	}
+	return MarketMode_TRADING
+}
+
+func (x *MarketState) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *MarketState) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type HaltMarketRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// must be USER_ROLE_ADMIN
+	UserRole      spot_instrument_v1.UserRole `protobuf:"varint,1,opt,name=user_role,json=userRole,proto3,enum=common.UserRole" json:"user_role,omitempty"`
+	MarketId      string                      `protobuf:"bytes,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Reason        string                      `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HaltMarketRequest) Reset() {
+	*x = HaltMarketRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HaltMarketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HaltMarketRequest) ProtoMessage() {}
+
+func (x *HaltMarketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HaltMarketRequest.ProtoReflect.Descriptor instead.
+func (*HaltMarketRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *HaltMarketRequest) GetUserRole() spot_instrument_v1.UserRole {
+	if x != nil {
+		return x.UserRole
+	}
+	return spot_instrument_v1.UserRole(0)
+}
+
+func (x *HaltMarketRequest) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *HaltMarketRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type HaltMarketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         *MarketState           `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HaltMarketResponse) Reset() {
+	*x = HaltMarketResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HaltMarketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HaltMarketResponse) ProtoMessage() {}
+
+func (x *HaltMarketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HaltMarketResponse.ProtoReflect.Descriptor instead.
+func (*HaltMarketResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *HaltMarketResponse) GetState() *MarketState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type ResumeMarketRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// must be USER_ROLE_ADMIN
+	UserRole      spot_instrument_v1.UserRole `protobuf:"varint,1,opt,name=user_role,json=userRole,proto3,enum=common.UserRole" json:"user_role,omitempty"`
+	MarketId      string                      `protobuf:"bytes,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Reason        string                      `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeMarketRequest) Reset() {
+	*x = ResumeMarketRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeMarketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeMarketRequest) ProtoMessage() {}
+
+func (x *ResumeMarketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeMarketRequest.ProtoReflect.Descriptor instead.
+func (*ResumeMarketRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ResumeMarketRequest) GetUserRole() spot_instrument_v1.UserRole {
+	if x != nil {
+		return x.UserRole
+	}
+	return spot_instrument_v1.UserRole(0)
+}
+
+func (x *ResumeMarketRequest) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *ResumeMarketRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ResumeMarketResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         *MarketState           `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeMarketResponse) Reset() {
+	*x = ResumeMarketResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeMarketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeMarketResponse) ProtoMessage() {}
+
+func (x *ResumeMarketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeMarketResponse.ProtoReflect.Descriptor instead.
+func (*ResumeMarketResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ResumeMarketResponse) GetState() *MarketState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type SetMarketCancelOnlyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// must be USER_ROLE_ADMIN
+	UserRole      spot_instrument_v1.UserRole `protobuf:"varint,1,opt,name=user_role,json=userRole,proto3,enum=common.UserRole" json:"user_role,omitempty"`
+	MarketId      string                      `protobuf:"bytes,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Reason        string                      `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMarketCancelOnlyRequest) Reset() {
+	*x = SetMarketCancelOnlyRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMarketCancelOnlyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMarketCancelOnlyRequest) ProtoMessage() {}
+
+func (x *SetMarketCancelOnlyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMarketCancelOnlyRequest.ProtoReflect.Descriptor instead.
+func (*SetMarketCancelOnlyRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SetMarketCancelOnlyRequest) GetUserRole() spot_instrument_v1.UserRole {
+	if x != nil {
+		return x.UserRole
+	}
+	return spot_instrument_v1.UserRole(0)
+}
+
+func (x *SetMarketCancelOnlyRequest) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *SetMarketCancelOnlyRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SetMarketCancelOnlyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         *MarketState           `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMarketCancelOnlyResponse) Reset() {
+	*x = SetMarketCancelOnlyResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMarketCancelOnlyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMarketCancelOnlyResponse) ProtoMessage() {}
+
+func (x *SetMarketCancelOnlyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMarketCancelOnlyResponse.ProtoReflect.Descriptor instead.
+func (*SetMarketCancelOnlyResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SetMarketCancelOnlyResponse) GetState() *MarketState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type StreamMarketStatesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// every market when empty
+	MarketId      string `protobuf:"bytes,1,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamMarketStatesRequest) Reset() {
+	*x = StreamMarketStatesRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamMarketStatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamMarketStatesRequest) ProtoMessage() {}
+
+func (x *StreamMarketStatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamMarketStatesRequest.ProtoReflect.Descriptor instead.
+func (*StreamMarketStatesRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *StreamMarketStatesRequest) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+type MassCancelRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// admins may cancel across users, other roles only their own orders
+	UserRole spot_instrument_v1.UserRole `protobuf:"varint,1,opt,name=user_role,json=userRole,proto3,enum=common.UserRole" json:"user_role,omitempty"`
+	// at least one of user_id and market_id is required
+	UserId   string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	MarketId string `protobuf:"bytes,3,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	// every side when unset
+	Side *Side `protobuf:"varint,4,opt,name=side,proto3,enum=order_service_v1.Side,oneof" json:"side,omitempty"`
+	// every open status when unset
+	Status        *Status `protobuf:"varint,5,opt,name=status,proto3,enum=order_service_v1.Status,oneof" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MassCancelRequest) Reset() {
+	*x = MassCancelRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MassCancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MassCancelRequest) ProtoMessage() {}
+
+func (x *MassCancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MassCancelRequest.ProtoReflect.Descriptor instead.
+func (*MassCancelRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *MassCancelRequest) GetUserRole() spot_instrument_v1.UserRole {
+	if x != nil {
+		return x.UserRole
+	}
+	return spot_instrument_v1.UserRole(0)
+}
+
+func (x *MassCancelRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MassCancelRequest) GetMarketId() string {
+	if x != nil {
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *MassCancelRequest) GetSide() Side {
+	if x != nil && x.Side != nil {
+		return *x.Side
+	}
+	return Side_BUY
+}
+
+func (x *MassCancelRequest) GetStatus() Status {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return Status_CREATED
+}
+
+type MassCancelResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CancelledOrderIds []string               `protobuf:"bytes,1,rep,name=cancelled_order_ids,json=cancelledOrderIds,proto3" json:"cancelled_order_ids,omitempty"`
+	Count             int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *MassCancelResponse) Reset() {
+	*x = MassCancelResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MassCancelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MassCancelResponse) ProtoMessage() {}
+
+func (x *MassCancelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MassCancelResponse.ProtoReflect.Descriptor instead.
+func (*MassCancelResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *MassCancelResponse) GetCancelledOrderIds() []string {
+	if x != nil {
+		return x.CancelledOrderIds
+	}
+	return nil
+}
+
+func (x *MassCancelResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type AmendOrderRequest struct {
+	state    protoimpl.MessageState      `protogen:"open.v1"`
+	UserRole spot_instrument_v1.UserRole `protobuf:"varint,1,opt,name=user_role,json=userRole,proto3,enum=common.UserRole" json:"user_role,omitempty"`
+	UserId   string                      `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OrderId  string                      `protobuf:"bytes,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// at least one of price and quantity is required, quantity is the new total
+	// including what is filled already
+	Price    *float64 `protobuf:"fixed64,4,opt,name=price,proto3,oneof" json:"price,omitempty"`
+	Quantity *float64 `protobuf:"fixed64,5,opt,name=quantity,proto3,oneof" json:"quantity,omitempty"`
+	// the version of the order the amend is based on, a different current version
+	// fails with ABORTED
+	ExpectedVersion int64 `protobuf:"varint,6,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AmendOrderRequest) Reset() {
+	*x = AmendOrderRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmendOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendOrderRequest) ProtoMessage() {}
+
+func (x *AmendOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *OrderStatusUpdateResponse) GetStatus() Status {
+// Deprecated: Use AmendOrderRequest.ProtoReflect.Descriptor instead.
+func (*AmendOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *AmendOrderRequest) GetUserRole() spot_instrument_v1.UserRole {
 	if x != nil {
-		return x.Status
+		return x.UserRole
 	}
-	return Status_CREATED
+	return spot_instrument_v1.UserRole(0)
 }
 
-type UpdateOrderStatusRequest struct {
+func (x *AmendOrderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AmendOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *AmendOrderRequest) GetPrice() float64 {
+	if x != nil && x.Price != nil {
+		return *x.Price
+	}
+	return 0
+}
+
+func (x *AmendOrderRequest) GetQuantity() float64 {
+	if x != nil && x.Quantity != nil {
+		return *x.Quantity
+	}
+	return 0
+}
+
+func (x *AmendOrderRequest) GetExpectedVersion() int64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type AmendOrderResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	Status        Status                 `protobuf:"varint,2,opt,name=status,proto3,enum=order_service_v1.Status" json:"status,omitempty"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOrderStatusRequest) Reset() {
-	*x = UpdateOrderStatusRequest{}
-	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[6]
+func (x *AmendOrderResponse) Reset() {
+	*x = AmendOrderResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrderStatusRequest) String() string {
+func (x *AmendOrderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrderStatusRequest) ProtoMessage() {}
+func (*AmendOrderResponse) ProtoMessage() {}
 
-func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[6]
+func (x *AmendOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -470,47 +4628,129 @@ func (x *UpdateOrderStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrderStatusRequest.ProtoReflect.Descriptor instead.
-func (*UpdateOrderStatusRequest) Descriptor() ([]byte, []int) {
-	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use AmendOrderResponse.ProtoReflect.Descriptor instead.
+func (*AmendOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *UpdateOrderStatusRequest) GetOrderId() string {
+func (x *AmendOrderResponse) GetOrder() *Order {
 	if x != nil {
-		return x.OrderId
+		return x.Order
+	}
+	return nil
+}
+
+type ListOrdersRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// optional filters
+	MarketId string  `protobuf:"bytes,2,opt,name=market_id,json=marketId,proto3" json:"market_id,omitempty"`
+	Side     *Side   `protobuf:"varint,3,opt,name=side,proto3,enum=order_service_v1.Side,oneof" json:"side,omitempty"`
+	Status   *Status `protobuf:"varint,4,opt,name=status,proto3,enum=order_service_v1.Status,oneof" json:"status,omitempty"`
+	// 100 when 0, at most 1000
+	PageSize      int32  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListOrdersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *UpdateOrderStatusRequest) GetStatus() Status {
+func (x *ListOrdersRequest) GetMarketId() string {
 	if x != nil {
-		return x.Status
+		return x.MarketId
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetSide() Side {
+	if x != nil && x.Side != nil {
+		return *x.Side
+	}
+	return Side_BUY
+}
+
+func (x *ListOrdersRequest) GetStatus() Status {
+	if x != nil && x.Status != nil {
+		return *x.Status
 	}
 	return Status_CREATED
 }
 
-type UpdateOrderStatusResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        Status                 `protobuf:"varint,1,opt,name=status,proto3,enum=order_service_v1.Status" json:"status,omitempty"`
+func (x *ListOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListOrdersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// oldest first
+	Orders []*Order `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// empty on the last page
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOrderStatusResponse) Reset() {
-	*x = UpdateOrderStatusResponse{}
-	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[7]
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrderStatusResponse) String() string {
+func (x *ListOrdersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrderStatusResponse) ProtoMessage() {}
+func (*ListOrdersResponse) ProtoMessage() {}
 
-func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[7]
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -521,28 +4761,178 @@ func (x *UpdateOrderStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrderStatusResponse.ProtoReflect.Descriptor instead.
-func (*UpdateOrderStatusResponse) Descriptor() ([]byte, []int) {
-	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *UpdateOrderStatusResponse) GetStatus() Status {
+func (x *ListOrdersResponse) GetOrders() []*Order {
 	if x != nil {
-		return x.Status
+		return x.Orders
 	}
-	return Status_CREATED
+	return nil
+}
+
+func (x *ListOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ValidateOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// idempotency_key is ignored
+	Order         *CreateOrderRequest `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateOrderRequest) Reset() {
+	*x = ValidateOrderRequest{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateOrderRequest) ProtoMessage() {}
+
+func (x *ValidateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateOrderRequest.ProtoReflect.Descriptor instead.
+func (*ValidateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ValidateOrderRequest) GetOrder() *CreateOrderRequest {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type ValidateOrderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// true when CreateOrder would accept the order right now
+	Valid bool `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// every rule the order breaks, empty when valid
+	Violations []*ItemError `protobuf:"bytes,2,rep,name=violations,proto3" json:"violations,omitempty"`
+	MakerRate  float64      `protobuf:"fixed64,3,opt,name=maker_rate,json=makerRate,proto3" json:"maker_rate,omitempty"`
+	TakerRate  float64      `protobuf:"fixed64,4,opt,name=taker_rate,json=takerRate,proto3" json:"taker_rate,omitempty"`
+	// fee when the whole order fills as taker, sells at a market price without a
+	// reference price have no estimate
+	EstimatedFee  float64 `protobuf:"fixed64,5,opt,name=estimated_fee,json=estimatedFee,proto3" json:"estimated_fee,omitempty"`
+	FeeAsset      string  `protobuf:"bytes,6,opt,name=fee_asset,json=feeAsset,proto3" json:"fee_asset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateOrderResponse) Reset() {
+	*x = ValidateOrderResponse{}
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateOrderResponse) ProtoMessage() {}
+
+func (x *ValidateOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_order_service_v1_order_service_messages_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateOrderResponse.ProtoReflect.Descriptor instead.
+func (*ValidateOrderResponse) Descriptor() ([]byte, []int) {
+	return file_order_service_v1_order_service_messages_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ValidateOrderResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateOrderResponse) GetViolations() []*ItemError {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+func (x *ValidateOrderResponse) GetMakerRate() float64 {
+	if x != nil {
+		return x.MakerRate
+	}
+	return 0
+}
+
+func (x *ValidateOrderResponse) GetTakerRate() float64 {
+	if x != nil {
+		return x.TakerRate
+	}
+	return 0
+}
+
+func (x *ValidateOrderResponse) GetEstimatedFee() float64 {
+	if x != nil {
+		return x.EstimatedFee
+	}
+	return 0
+}
+
+func (x *ValidateOrderResponse) GetFeeAsset() string {
+	if x != nil {
+		return x.FeeAsset
+	}
+	return ""
 }
 
 var File_order_service_v1_order_service_messages_proto protoreflect.FileDescriptor
 
 const file_order_service_v1_order_service_messages_proto_rawDesc = "" +
 	"\n" +
-	"-order_service_v1/order_service_messages.proto\x12\x10order_service_v1\x1a\x19common/proto/common.proto\"K\n" +
+	"-order_service_v1/order_service_messages.proto\x12\x10order_service_v1\x1a\x19common/proto/common.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"s\n" +
 	"\x15GetOrderStatusRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"J\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12&\n" +
+	"\x0fclient_order_id\x18\x03 \x01(\tR\rclientOrderId\"\x81\x03\n" +
 	"\x16GetOrderStatusResponse\x120\n" +
-	"\x06status\x18\x01 \x01(\x0e2\x18.order_service_v1.StatusR\x06status\"\xe7\x01\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x18.order_service_v1.StatusR\x06status\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x12C\n" +
+	"\rstatus_reason\x18\x03 \x01(\x0e2\x1e.order_service_v1.StatusReasonR\fstatusReason\x12#\n" +
+	"\rstatus_detail\x18\x04 \x01(\tR\fstatusDetail\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12;\n" +
+	"\vterminal_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"terminalAt\"\xff\x03\n" +
 	"\x12CreateOrderRequest\x12-\n" +
 	"\tuser_role\x18\x01 \x01(\x0e2\x10.common.UserRoleR\buserRole\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
@@ -550,27 +4940,404 @@ const file_order_service_v1_order_service_messages_proto_rawDesc = "" +
 	"\n" +
 	"order_type\x18\x04 \x01(\x0e2\x1b.order_service_v1.OrderTypeR\torderType\x12\x14\n" +
 	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1a\n" +
-	"\bquantity\x18\x06 \x01(\x01R\bquantity\"b\n" +
+	"\bquantity\x18\x06 \x01(\x01R\bquantity\x12'\n" +
+	"\x0fidempotency_key\x18\a \x01(\tR\x0eidempotencyKey\x12&\n" +
+	"\x0fclient_order_id\x18\b \x01(\tR\rclientOrderId\x12A\n" +
+	"\rtime_in_force\x18\t \x01(\x0e2\x1d.order_service_v1.TimeInForceR\vtimeInForce\x127\n" +
+	"\texpire_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\x12*\n" +
+	"\x04side\x18\v \x01(\x0e2\x16.order_service_v1.SideR\x04side\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\f \x01(\x01R\tstopPrice\"\xc1\x03\n" +
 	"\x13CreateOrderResponse\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x120\n" +
-	"\x06status\x18\x02 \x01(\x0e2\x18.order_service_v1.StatusR\x06status\"J\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x18.order_service_v1.StatusR\x06status\x12&\n" +
+	"\x0fclient_order_id\x18\x03 \x01(\tR\rclientOrderId\x12C\n" +
+	"\rstatus_reason\x18\x04 \x01(\x0e2\x1e.order_service_v1.StatusReasonR\fstatusReason\x12#\n" +
+	"\rstatus_detail\x18\x05 \x01(\tR\fstatusDetail\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\a \x01(\x03R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vterminal_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"terminalAt\"\x9e\b\n" +
+	"\x05Order\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tmarket_id\x18\x03 \x01(\tR\bmarketId\x12:\n" +
+	"\n" +
+	"order_type\x18\x04 \x01(\x0e2\x1b.order_service_v1.OrderTypeR\torderType\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x01R\bquantity\x120\n" +
+	"\x06status\x18\a \x01(\x0e2\x18.order_service_v1.StatusR\x06status\x12&\n" +
+	"\x0fclient_order_id\x18\b \x01(\tR\rclientOrderId\x12A\n" +
+	"\rtime_in_force\x18\t \x01(\x0e2\x1d.order_service_v1.TimeInForceR\vtimeInForce\x127\n" +
+	"\texpire_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\x12*\n" +
+	"\x04side\x18\v \x01(\x0e2\x16.order_service_v1.SideR\x04side\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\f \x01(\x01R\tstopPrice\x12=\n" +
+	"\ftriggered_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\vtriggeredAt\x126\n" +
+	"\ahistory\x18\x0e \x03(\v2\x1c.order_service_v1.OrderEventR\ahistory\x12'\n" +
+	"\x0ffilled_quantity\x18\x0f \x01(\x01R\x0efilledQuantity\x12$\n" +
+	"\x0eavg_fill_price\x18\x10 \x01(\x01R\favgFillPrice\x12\x1b\n" +
+	"\tfee_total\x18\x11 \x01(\x01R\bfeeTotal\x12\x1b\n" +
+	"\tfee_asset\x18\x12 \x01(\tR\bfeeAsset\x12\x18\n" +
+	"\aversion\x18\x13 \x01(\x03R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12;\n" +
+	"\vterminal_at\x18\x16 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"terminalAt\x12C\n" +
+	"\rstatus_reason\x18\x17 \x01(\x0e2\x1e.order_service_v1.StatusReasonR\fstatusReason\x12#\n" +
+	"\rstatus_detail\x18\x18 \x01(\tR\fstatusDetail\"d\n" +
+	"\n" +
+	"OrderEvent\x12*\n" +
+	"\x02at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06detail\x18\x03 \x01(\tR\x06detail\"m\n" +
+	"\x0fGetOrderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12&\n" +
+	"\x0fclient_order_id\x18\x03 \x01(\tR\rclientOrderId\"A\n" +
+	"\x10GetOrderResponse\x12-\n" +
+	"\x05order\x18\x01 \x01(\v2\x17.order_service_v1.OrderR\x05order\"\x9b\x01\n" +
+	"\x12CancelOrderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12&\n" +
+	"\x0fclient_order_id\x18\x03 \x01(\tR\rclientOrderId\x12)\n" +
+	"\x10expected_version\x18\x04 \x01(\x03R\x0fexpectedVersion\"\x99\x03\n" +
+	"\x13CancelOrderResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x120\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x18.order_service_v1.StatusR\x06status\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x03R\aversion\x12C\n" +
+	"\rstatus_reason\x18\x04 \x01(\x0e2\x1e.order_service_v1.StatusReasonR\fstatusReason\x12#\n" +
+	"\rstatus_detail\x18\x05 \x01(\tR\fstatusDetail\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vterminal_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"terminalAt\"\x95\x01\n" +
 	"\x19StreamOrderUpdatesRequest\x12-\n" +
-	"\tuser_role\x18\x01 \x01(\x0e2\x10.common.UserRoleR\buserRole\"M\n" +
+	"\tuser_role\x18\x01 \x01(\x0e2\x10.common.UserRoleR\buserRole\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x120\n" +
+	"\x14cancel_on_disconnect\x18\x03 \x01(\bR\x12cancelOnDisconnect\"\x9b\x01\n" +
 	"\x19OrderStatusUpdateResponse\x120\n" +
-	"\x06status\x18\x01 \x01(\x0e2\x18.order_service_v1.StatusR\x06status\"g\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x18.order_service_v1.StatusR\x06status\x12-\n" +
+	"\x05order\x18\x02 \x01(\v2\x17.order_service_v1.OrderR\x05order\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x03 \x01(\tR\tsessionId\"Y\n" +
+	"\x1fCloseOrderUpdatesSessionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\"\"\n" +
+	" CloseOrderUpdatesSessionResponse\"O\n" +
+	"\x15CancelAllAfterRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"timeout_ms\x18\x02 \x01(\x03R\ttimeoutMs\"Q\n" +
+	"\x16CancelAllAfterResponse\x127\n" +
+	"\tcancel_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\bcancelAt\"\x92\x01\n" +
 	"\x18UpdateOrderStatusRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\tR\aorderId\x120\n" +
-	"\x06status\x18\x02 \x01(\x0e2\x18.order_service_v1.StatusR\x06status\"M\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x18.order_service_v1.StatusR\x06status\x12)\n" +
+	"\x10expected_version\x18\x03 \x01(\x03R\x0fexpectedVersion\"\x84\x03\n" +
 	"\x19UpdateOrderStatusResponse\x120\n" +
-	"\x06status\x18\x01 \x01(\x0e2\x18.order_service_v1.StatusR\x06status*4\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x18.order_service_v1.StatusR\x06status\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x12C\n" +
+	"\rstatus_reason\x18\x03 \x01(\x0e2\x1e.order_service_v1.StatusReasonR\fstatusReason\x12#\n" +
+	"\rstatus_detail\x18\x04 \x01(\tR\fstatusDetail\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vterminal_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"terminalAt\"}\n" +
+	"\x1bRefreshMarketCatalogRequest\x12/\n" +
+	"\n" +
+	"user_roles\x18\x01 \x03(\x0e2\x10.common.UserRoleR\tuserRoles\x12-\n" +
+	"\tuser_role\x18\x02 \x01(\x0e2\x10.common.UserRoleR\buserRole\"\xb9\x01\n" +
+	"\x12MarketCatalogState\x12-\n" +
+	"\tuser_role\x18\x01 \x01(\x0e2\x10.common.UserRoleR\buserRole\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x04R\aversion\x12!\n" +
+	"\fmarket_count\x18\x03 \x01(\x05R\vmarketCount\x127\n" +
+	"\tsynced_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bsyncedAt\"`\n" +
+	"\x1cRefreshMarketCatalogResponse\x12@\n" +
+	"\bcatalogs\x18\x01 \x03(\v2$.order_service_v1.MarketCatalogStateR\bcatalogs\"Q\n" +
+	"\tItemError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xcc\x01\n" +
+	"\x13CreateOrdersRequest\x12-\n" +
+	"\tuser_role\x18\x01 \x01(\x0e2\x10.common.UserRoleR\buserRole\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12<\n" +
+	"\x06orders\x18\x03 \x03(\v2$.order_service_v1.CreateOrderRequestR\x06orders\x12/\n" +
+	"\x04mode\x18\x04 \x01(\x0e2\x1b.order_service_v1.BatchModeR\x04mode\"\x88\x04\n" +
+	"\x11CreateOrderResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x120\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x18.order_service_v1.StatusR\x06status\x12&\n" +
+	"\x0fclient_order_id\x18\x04 \x01(\tR\rclientOrderId\x121\n" +
+	"\x05error\x18\x05 \x01(\v2\x1b.order_service_v1.ItemErrorR\x05error\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x03R\aversion\x12C\n" +
+	"\rstatus_reason\x18\a \x01(\x0e2\x1e.order_service_v1.StatusReasonR\fstatusReason\x12#\n" +
+	"\rstatus_detail\x18\b \x01(\tR\fstatusDetail\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vterminal_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"terminalAt\"U\n" +
+	"\x14CreateOrdersResponse\x12=\n" +
+	"\aresults\x18\x01 \x03(\v2#.order_service_v1.CreateOrderResultR\aresults\"\x9d\x01\n" +
+	"\x13CancelOrdersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12<\n" +
+	"\x06orders\x18\x02 \x03(\v2$.order_service_v1.CancelOrderRequestR\x06orders\x12/\n" +
+	"\x04mode\x18\x03 \x01(\x0e2\x1b.order_service_v1.BatchModeR\x04mode\"\xe0\x03\n" +
+	"\x11CancelOrderResult\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x120\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x18.order_service_v1.StatusR\x06status\x121\n" +
+	"\x05error\x18\x04 \x01(\v2\x1b.order_service_v1.ItemErrorR\x05error\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\x12C\n" +
+	"\rstatus_reason\x18\x06 \x01(\x0e2\x1e.order_service_v1.StatusReasonR\fstatusReason\x12#\n" +
+	"\rstatus_detail\x18\a \x01(\tR\fstatusDetail\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vterminal_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"terminalAt\"U\n" +
+	"\x14CancelOrdersResponse\x12=\n" +
+	"\aresults\x18\x01 \x03(\v2#.order_service_v1.CancelOrderResultR\aresults\"|\n" +
+	"\x18SetReferencePriceRequest\x12\x1b\n" +
+	"\tmarket_id\x18\x01 \x01(\tR\bmarketId\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\x12-\n" +
+	"\tuser_role\x18\x03 \x01(\x0e2\x10.common.UserRoleR\buserRole\"K\n" +
+	"\x19SetReferencePriceResponse\x12.\n" +
+	"\x13triggered_order_ids\x18\x01 \x03(\tR\x11triggeredOrderIds\"_\n" +
+	"\n" +
+	"PriceLevel\x12\x14\n" +
+	"\x05price\x18\x01 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x01R\bquantity\x12\x1f\n" +
+	"\vorder_count\x18\x03 \x01(\x05R\n" +
+	"orderCount\"H\n" +
+	"\x13GetOrderBookRequest\x12\x1b\n" +
+	"\tmarket_id\x18\x01 \x01(\tR\bmarketId\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depth\"\xb3\x01\n" +
+	"\x14GetOrderBookResponse\x12\x1b\n" +
+	"\tmarket_id\x18\x01 \x01(\tR\bmarketId\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x04R\bsequence\x120\n" +
+	"\x04bids\x18\x03 \x03(\v2\x1c.order_service_v1.PriceLevelR\x04bids\x120\n" +
+	"\x04asks\x18\x04 \x03(\v2\x1c.order_service_v1.PriceLevelR\x04asks\"5\n" +
+	"\x16StreamOrderBookRequest\x12\x1b\n" +
+	"\tmarket_id\x18\x01 \x01(\tR\bmarketId\"m\n" +
+	"\vLevelUpdate\x12*\n" +
+	"\x04side\x18\x01 \x01(\x0e2\x16.order_service_v1.SideR\x04side\x122\n" +
+	"\x05level\x18\x02 \x01(\v2\x1c.order_service_v1.PriceLevelR\x05level\"\x83\x02\n" +
+	"\x0fOrderBookUpdate\x12\x1b\n" +
+	"\tmarket_id\x18\x01 \x01(\tR\bmarketId\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x04R\bsequence\x12\x1a\n" +
+	"\bsnapshot\x18\x03 \x01(\bR\bsnapshot\x120\n" +
+	"\x04bids\x18\x04 \x03(\v2\x1c.order_service_v1.PriceLevelR\x04bids\x120\n" +
+	"\x04asks\x18\x05 \x03(\v2\x1c.order_service_v1.PriceLevelR\x04asks\x127\n" +
+	"\aupdates\x18\x06 \x03(\v2\x1d.order_service_v1.LevelUpdateR\aupdates\"\xa9\x03\n" +
+	"\x05Trade\x12\x19\n" +
+	"\btrade_id\x18\x01 \x01(\tR\atradeId\x12\x1b\n" +
+	"\tmarket_id\x18\x02 \x01(\tR\bmarketId\x12 \n" +
+	"\fbuy_order_id\x18\x03 \x01(\tR\n" +
+	"buyOrderId\x12\"\n" +
+	"\rsell_order_id\x18\x04 \x01(\tR\vsellOrderId\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x01R\bquantity\x12;\n" +
+	"\vexecuted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"executedAt\x125\n" +
+	"\n" +
+	"taker_side\x18\b \x01(\x0e2\x16.order_service_v1.SideR\ttakerSide\x12\x17\n" +
+	"\abuy_fee\x18\t \x01(\x01R\x06buyFee\x12\"\n" +
+	"\rbuy_fee_asset\x18\n" +
+	" \x01(\tR\vbuyFeeAsset\x12\x19\n" +
+	"\bsell_fee\x18\v \x01(\x01R\asellFee\x12$\n" +
+	"\x0esell_fee_asset\x18\f \x01(\tR\fsellFeeAsset\"\x85\x01\n" +
+	"\x11ListTradesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tmarket_id\x18\x02 \x01(\tR\bmarketId\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"m\n" +
+	"\x12ListTradesResponse\x12/\n" +
+	"\x06trades\x18\x01 \x03(\v2\x17.order_service_v1.TradeR\x06trades\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"Y\n" +
+	"\aBalance\x12\x14\n" +
+	"\x05asset\x18\x01 \x01(\tR\x05asset\x12\x1c\n" +
+	"\tavailable\x18\x02 \x01(\x01R\tavailable\x12\x1a\n" +
+	"\breserved\x18\x03 \x01(\x01R\breserved\"\xaa\x01\n" +
+	"\x14CreditAccountRequest\x12-\n" +
+	"\tuser_role\x18\x01 \x01(\x0e2\x10.common.UserRoleR\buserRole\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05asset\x18\x03 \x01(\tR\x05asset\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x1c\n" +
+	"\treference\x18\x05 \x01(\tR\treference\"L\n" +
+	"\x15CreditAccountResponse\x123\n" +
+	"\abalance\x18\x01 \x01(\v2\x19.order_service_v1.BalanceR\abalance\"\xa9\x01\n" +
+	"\x13DebitAccountRequest\x12-\n" +
+	"\tuser_role\x18\x01 \x01(\x0e2\x10.common.UserRoleR\buserRole\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05asset\x18\x03 \x01(\tR\x05asset\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x1c\n" +
+	"\treference\x18\x05 \x01(\tR\treference\"K\n" +
+	"\x14DebitAccountResponse\x123\n" +
+	"\abalance\x18\x01 \x01(\v2\x19.order_service_v1.BalanceR\abalance\"-\n" +
+	"\x12GetBalancesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"L\n" +
+	"\x13GetBalancesResponse\x125\n" +
+	"\bbalances\x18\x01 \x03(\v2\x19.order_service_v1.BalanceR\bbalances\"\xe9\x01\n" +
+	"\bPosition\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tmarket_id\x18\x02 \x01(\tR\bmarketId\x12!\n" +
+	"\fnet_quantity\x18\x03 \x01(\x01R\vnetQuantity\x12&\n" +
+	"\x0favg_entry_price\x18\x04 \x01(\x01R\ravgEntryPrice\x12!\n" +
+	"\frealized_pnl\x18\x05 \x01(\x01R\vrealizedPnl\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"K\n" +
+	"\x13GetPositionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tmarket_id\x18\x02 \x01(\tR\bmarketId\"P\n" +
+	"\x14GetPositionsResponse\x128\n" +
+	"\tpositions\x18\x01 \x03(\v2\x1a.order_service_v1.PositionR\tpositions\"1\n" +
+	"\x16StreamPositionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"d\n" +
+	"\x0ePositionUpdate\x126\n" +
+	"\bposition\x18\x01 \x01(\v2\x1a.order_service_v1.PositionR\bposition\x12\x1a\n" +
+	"\bsnapshot\x18\x02 \x01(\bR\bsnapshot\"\xaf\x01\n" +
+	"\vMarketState\x12\x1b\n" +
+	"\tmarket_id\x18\x01 \x01(\tR\bmarketId\x120\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x1c.order_service_v1.MarketModeR\x04mode\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"w\n" +
+	"\x11HaltMarketRequest\x12-\n" +
+	"\tuser_role\x18\x01 \x01(\x0e2\x10.common.UserRoleR\buserRole\x12\x1b\n" +
+	"\tmarket_id\x18\x02 \x01(\tR\bmarketId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"I\n" +
+	"\x12HaltMarketResponse\x123\n" +
+	"\x05state\x18\x01 \x01(\v2\x1d.order_service_v1.MarketStateR\x05state\"y\n" +
+	"\x13ResumeMarketRequest\x12-\n" +
+	"\tuser_role\x18\x01 \x01(\x0e2\x10.common.UserRoleR\buserRole\x12\x1b\n" +
+	"\tmarket_id\x18\x02 \x01(\tR\bmarketId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"K\n" +
+	"\x14ResumeMarketResponse\x123\n" +
+	"\x05state\x18\x01 \x01(\v2\x1d.order_service_v1.MarketStateR\x05state\"\x80\x01\n" +
+	"\x1aSetMarketCancelOnlyRequest\x12-\n" +
+	"\tuser_role\x18\x01 \x01(\x0e2\x10.common.UserRoleR\buserRole\x12\x1b\n" +
+	"\tmarket_id\x18\x02 \x01(\tR\bmarketId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"R\n" +
+	"\x1bSetMarketCancelOnlyResponse\x123\n" +
+	"\x05state\x18\x01 \x01(\v2\x1d.order_service_v1.MarketStateR\x05state\"8\n" +
+	"\x19StreamMarketStatesRequest\x12\x1b\n" +
+	"\tmarket_id\x18\x01 \x01(\tR\bmarketId\"\xf4\x01\n" +
+	"\x11MassCancelRequest\x12-\n" +
+	"\tuser_role\x18\x01 \x01(\x0e2\x10.common.UserRoleR\buserRole\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tmarket_id\x18\x03 \x01(\tR\bmarketId\x12/\n" +
+	"\x04side\x18\x04 \x01(\x0e2\x16.order_service_v1.SideH\x00R\x04side\x88\x01\x01\x125\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x18.order_service_v1.StatusH\x01R\x06status\x88\x01\x01B\a\n" +
+	"\x05_sideB\t\n" +
+	"\a_status\"Z\n" +
+	"\x12MassCancelResponse\x12.\n" +
+	"\x13cancelled_order_ids\x18\x01 \x03(\tR\x11cancelledOrderIds\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"\xf4\x01\n" +
+	"\x11AmendOrderRequest\x12-\n" +
+	"\tuser_role\x18\x01 \x01(\x0e2\x10.common.UserRoleR\buserRole\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\tR\aorderId\x12\x19\n" +
+	"\x05price\x18\x04 \x01(\x01H\x00R\x05price\x88\x01\x01\x12\x1f\n" +
+	"\bquantity\x18\x05 \x01(\x01H\x01R\bquantity\x88\x01\x01\x12)\n" +
+	"\x10expected_version\x18\x06 \x01(\x03R\x0fexpectedVersionB\b\n" +
+	"\x06_priceB\v\n" +
+	"\t_quantity\"C\n" +
+	"\x12AmendOrderResponse\x12-\n" +
+	"\x05order\x18\x01 \x01(\v2\x17.order_service_v1.OrderR\x05order\"\x81\x02\n" +
+	"\x11ListOrdersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tmarket_id\x18\x02 \x01(\tR\bmarketId\x12/\n" +
+	"\x04side\x18\x03 \x01(\x0e2\x16.order_service_v1.SideH\x00R\x04side\x88\x01\x01\x125\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x18.order_service_v1.StatusH\x01R\x06status\x88\x01\x01\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageTokenB\a\n" +
+	"\x05_sideB\t\n" +
+	"\a_status\"m\n" +
+	"\x12ListOrdersResponse\x12/\n" +
+	"\x06orders\x18\x01 \x03(\v2\x17.order_service_v1.OrderR\x06orders\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"R\n" +
+	"\x14ValidateOrderRequest\x12:\n" +
+	"\x05order\x18\x01 \x01(\v2$.order_service_v1.CreateOrderRequestR\x05order\"\xea\x01\n" +
+	"\x15ValidateOrderResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12;\n" +
+	"\n" +
+	"violations\x18\x02 \x03(\v2\x1b.order_service_v1.ItemErrorR\n" +
+	"violations\x12\x1d\n" +
+	"\n" +
+	"maker_rate\x18\x03 \x01(\x01R\tmakerRate\x12\x1d\n" +
+	"\n" +
+	"taker_rate\x18\x04 \x01(\x01R\ttakerRate\x12#\n" +
+	"\restimated_fee\x18\x05 \x01(\x01R\festimatedFee\x12\x1b\n" +
+	"\tfee_asset\x18\x06 \x01(\tR\bfeeAsset*a\n" +
 	"\x06Status\x12\v\n" +
 	"\aCREATED\x10\x00\x12\x0e\n" +
 	"\n" +
 	"PROCESSING\x10\x01\x12\r\n" +
-	"\tPROCESSED\x10\x02*.\n" +
+	"\tPROCESSED\x10\x02\x12\r\n" +
+	"\tCANCELLED\x10\x03\x12\v\n" +
+	"\aEXPIRED\x10\x04\x12\x0f\n" +
+	"\vUNTRIGGERED\x10\x05*\xea\x02\n" +
+	"\fStatusReason\x12\r\n" +
+	"\tNO_REASON\x10\x00\x12\n" +
+	"\n" +
+	"\x06FILLED\x10\x01\x12\x14\n" +
+	"\x10PARTIALLY_FILLED\x10\x02\x12\r\n" +
+	"\tTRIGGERED\x10\x03\x12\x15\n" +
+	"\x11CANCELLED_BY_USER\x10\x04\x12\x16\n" +
+	"\x12CANCELLED_BY_ADMIN\x10\x05\x12\x1b\n" +
+	"\x17CANCELLED_ON_DISCONNECT\x10\x06\x12 \n" +
+	"\x1cCANCELLED_BY_DEAD_MAN_SWITCH\x10\a\x12\x0f\n" +
+	"\vGTD_EXPIRED\x10\b\x12\x1e\n" +
+	"\x1aUNFILLED_REMAINDER_EXPIRED\x10\t\x12\x14\n" +
+	"\x10FOK_NOT_FILLABLE\x10\n" +
+	"\x12\x12\n" +
+	"\x0eSTATUS_UPDATED\x10\v\x12\x14\n" +
+	"\x10REJECTED_BY_RISK\x10\f\x12\x1f\n" +
+	"\x1bREJECTED_INSUFFICIENT_FUNDS\x10\r\x12\x1a\n" +
+	"\x16REJECTED_MARKET_HALTED\x10\x0e*\x19\n" +
+	"\x04Side\x12\a\n" +
+	"\x03BUY\x10\x00\x12\b\n" +
+	"\x04SELL\x10\x01*1\n" +
+	"\vTimeInForce\x12\a\n" +
+	"\x03GTC\x10\x00\x12\a\n" +
+	"\x03IOC\x10\x01\x12\a\n" +
+	"\x03FOK\x10\x02\x12\a\n" +
+	"\x03GTD\x10\x03*O\n" +
 	"\tOrderType\x12\x10\n" +
 	"\fMARKET_ORDER\x10\x00\x12\x0f\n" +
-	"\vLIMIT_ORDER\x10\x01B*Z(github.com/ewik2k21/grpcOrderService/pkgb\x06proto3"
+	"\vLIMIT_ORDER\x10\x01\x12\x0f\n" +
+	"\vSTOP_MARKET\x10\x02\x12\x0e\n" +
+	"\n" +
+	"STOP_LIMIT\x10\x03*0\n" +
+	"\tBatchMode\x12\x12\n" +
+	"\x0eALL_OR_NOTHING\x10\x00\x12\x0f\n" +
+	"\vBEST_EFFORT\x10\x01*6\n" +
+	"\n" +
+	"MarketMode\x12\v\n" +
+	"\aTRADING\x10\x00\x12\n" +
+	"\n" +
+	"\x06HALTED\x10\x01\x12\x0f\n" +
+	"\vCANCEL_ONLY\x10\x02B*Z(github.com/ewik2k21/grpcOrderService/pkgb\x06proto3"
 
 var (
 	file_order_service_v1_order_service_messages_proto_rawDescOnce sync.Once
@@ -584,35 +5351,196 @@ func file_order_service_v1_order_service_messages_proto_rawDescGZIP() []byte {
 	return file_order_service_v1_order_service_messages_proto_rawDescData
 }
 
-var file_order_service_v1_order_service_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_order_service_v1_order_service_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_order_service_v1_order_service_messages_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
+var file_order_service_v1_order_service_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 67)
 var file_order_service_v1_order_service_messages_proto_goTypes = []any{
-	(Status)(0),                       // 0: order_service_v1.Status
-	(OrderType)(0),                    // 1: order_service_v1.OrderType
-	(*GetOrderStatusRequest)(nil),     // 2: order_service_v1.GetOrderStatusRequest
-	(*GetOrderStatusResponse)(nil),    // 3: order_service_v1.GetOrderStatusResponse
-	(*CreateOrderRequest)(nil),        // 4: order_service_v1.CreateOrderRequest
-	(*CreateOrderResponse)(nil),       // 5: order_service_v1.CreateOrderResponse
-	(*StreamOrderUpdatesRequest)(nil), // 6: order_service_v1.StreamOrderUpdatesRequest
-	(*OrderStatusUpdateResponse)(nil), // 7: order_service_v1.OrderStatusUpdateResponse
-	(*UpdateOrderStatusRequest)(nil),  // 8: order_service_v1.UpdateOrderStatusRequest
-	(*UpdateOrderStatusResponse)(nil), // 9: order_service_v1.UpdateOrderStatusResponse
-	(spot_instrument_v1.UserRole)(0),  // 10: common.UserRole
+	(Status)(0),                              // 0: order_service_v1.Status
+	(StatusReason)(0),                        // 1: order_service_v1.StatusReason
+	(Side)(0),                                // 2: order_service_v1.Side
+	(TimeInForce)(0),                         // 3: order_service_v1.TimeInForce
+	(OrderType)(0),                           // 4: order_service_v1.OrderType
+	(BatchMode)(0),                           // 5: order_service_v1.BatchMode
+	(MarketMode)(0),                          // 6: order_service_v1.MarketMode
+	(*GetOrderStatusRequest)(nil),            // 7: order_service_v1.GetOrderStatusRequest
+	(*GetOrderStatusResponse)(nil),           // 8: order_service_v1.GetOrderStatusResponse
+	(*CreateOrderRequest)(nil),               // 9: order_service_v1.CreateOrderRequest
+	(*CreateOrderResponse)(nil),              // 10: order_service_v1.CreateOrderResponse
+	(*Order)(nil),                            // 11: order_service_v1.Order
+	(*OrderEvent)(nil),                       // 12: order_service_v1.OrderEvent
+	(*GetOrderRequest)(nil),                  // 13: order_service_v1.GetOrderRequest
+	(*GetOrderResponse)(nil),                 // 14: order_service_v1.GetOrderResponse
+	(*CancelOrderRequest)(nil),               // 15: order_service_v1.CancelOrderRequest
+	(*CancelOrderResponse)(nil),              // 16: order_service_v1.CancelOrderResponse
+	(*StreamOrderUpdatesRequest)(nil),        // 17: order_service_v1.StreamOrderUpdatesRequest
+	(*OrderStatusUpdateResponse)(nil),        // 18: order_service_v1.OrderStatusUpdateResponse
+	(*CloseOrderUpdatesSessionRequest)(nil),  // 19: order_service_v1.CloseOrderUpdatesSessionRequest
+	(*CloseOrderUpdatesSessionResponse)(nil), // 20: order_service_v1.CloseOrderUpdatesSessionResponse
+	(*CancelAllAfterRequest)(nil),            // 21: order_service_v1.CancelAllAfterRequest
+	(*CancelAllAfterResponse)(nil),           // 22: order_service_v1.CancelAllAfterResponse
+	(*UpdateOrderStatusRequest)(nil),         // 23: order_service_v1.UpdateOrderStatusRequest
+	(*UpdateOrderStatusResponse)(nil),        // 24: order_service_v1.UpdateOrderStatusResponse
+	(*RefreshMarketCatalogRequest)(nil),      // 25: order_service_v1.RefreshMarketCatalogRequest
+	(*MarketCatalogState)(nil),               // 26: order_service_v1.MarketCatalogState
+	(*RefreshMarketCatalogResponse)(nil),     // 27: order_service_v1.RefreshMarketCatalogResponse
+	(*ItemError)(nil),                        // 28: order_service_v1.ItemError
+	(*CreateOrdersRequest)(nil),              // 29: order_service_v1.CreateOrdersRequest
+	(*CreateOrderResult)(nil),                // 30: order_service_v1.CreateOrderResult
+	(*CreateOrdersResponse)(nil),             // 31: order_service_v1.CreateOrdersResponse
+	(*CancelOrdersRequest)(nil),              // 32: order_service_v1.CancelOrdersRequest
+	(*CancelOrderResult)(nil),                // 33: order_service_v1.CancelOrderResult
+	(*CancelOrdersResponse)(nil),             // 34: order_service_v1.CancelOrdersResponse
+	(*SetReferencePriceRequest)(nil),         // 35: order_service_v1.SetReferencePriceRequest
+	(*SetReferencePriceResponse)(nil),        // 36: order_service_v1.SetReferencePriceResponse
+	(*PriceLevel)(nil),                       // 37: order_service_v1.PriceLevel
+	(*GetOrderBookRequest)(nil),              // 38: order_service_v1.GetOrderBookRequest
+	(*GetOrderBookResponse)(nil),             // 39: order_service_v1.GetOrderBookResponse
+	(*StreamOrderBookRequest)(nil),           // 40: order_service_v1.StreamOrderBookRequest
+	(*LevelUpdate)(nil),                      // 41: order_service_v1.LevelUpdate
+	(*OrderBookUpdate)(nil),                  // 42: order_service_v1.OrderBookUpdate
+	(*Trade)(nil),                            // 43: order_service_v1.Trade
+	(*ListTradesRequest)(nil),                // 44: order_service_v1.ListTradesRequest
+	(*ListTradesResponse)(nil),               // 45: order_service_v1.ListTradesResponse
+	(*Balance)(nil),                          // 46: order_service_v1.Balance
+	(*CreditAccountRequest)(nil),             // 47: order_service_v1.CreditAccountRequest
+	(*CreditAccountResponse)(nil),            // 48: order_service_v1.CreditAccountResponse
+	(*DebitAccountRequest)(nil),              // 49: order_service_v1.DebitAccountRequest
+	(*DebitAccountResponse)(nil),             // 50: order_service_v1.DebitAccountResponse
+	(*GetBalancesRequest)(nil),               // 51: order_service_v1.GetBalancesRequest
+	(*GetBalancesResponse)(nil),              // 52: order_service_v1.GetBalancesResponse
+	(*Position)(nil),                         // 53: order_service_v1.Position
+	(*GetPositionsRequest)(nil),              // 54: order_service_v1.GetPositionsRequest
+	(*GetPositionsResponse)(nil),             // 55: order_service_v1.GetPositionsResponse
+	(*StreamPositionsRequest)(nil),           // 56: order_service_v1.StreamPositionsRequest
+	(*PositionUpdate)(nil),                   // 57: order_service_v1.PositionUpdate
+	(*MarketState)(nil),                      // 58: order_service_v1.MarketState
+	(*HaltMarketRequest)(nil),                // 59: order_service_v1.HaltMarketRequest
+	(*HaltMarketResponse)(nil),               // 60: order_service_v1.HaltMarketResponse
+	(*ResumeMarketRequest)(nil),              // 61: order_service_v1.ResumeMarketRequest
+	(*ResumeMarketResponse)(nil),             // 62: order_service_v1.ResumeMarketResponse
+	(*SetMarketCancelOnlyRequest)(nil),       // 63: order_service_v1.SetMarketCancelOnlyRequest
+	(*SetMarketCancelOnlyResponse)(nil),      // 64: order_service_v1.SetMarketCancelOnlyResponse
+	(*StreamMarketStatesRequest)(nil),        // 65: order_service_v1.StreamMarketStatesRequest
+	(*MassCancelRequest)(nil),                // 66: order_service_v1.MassCancelRequest
+	(*MassCancelResponse)(nil),               // 67: order_service_v1.MassCancelResponse
+	(*AmendOrderRequest)(nil),                // 68: order_service_v1.AmendOrderRequest
+	(*AmendOrderResponse)(nil),               // 69: order_service_v1.AmendOrderResponse
+	(*ListOrdersRequest)(nil),                // 70: order_service_v1.ListOrdersRequest
+	(*ListOrdersResponse)(nil),               // 71: order_service_v1.ListOrdersResponse
+	(*ValidateOrderRequest)(nil),             // 72: order_service_v1.ValidateOrderRequest
+	(*ValidateOrderResponse)(nil),            // 73: order_service_v1.ValidateOrderResponse
+	(*timestamppb.Timestamp)(nil),            // 74: google.protobuf.Timestamp
+	(spot_instrument_v1.UserRole)(0),         // 75: common.UserRole
 }
 var file_order_service_v1_order_service_messages_proto_depIdxs = []int32{
-	0,  // 0: order_service_v1.GetOrderStatusResponse.status:type_name -> order_service_v1.Status
-	10, // 1: order_service_v1.CreateOrderRequest.user_role:type_name -> common.UserRole
-	1,  // 2: order_service_v1.CreateOrderRequest.order_type:type_name -> order_service_v1.OrderType
-	0,  // 3: order_service_v1.CreateOrderResponse.status:type_name -> order_service_v1.Status
-	10, // 4: order_service_v1.StreamOrderUpdatesRequest.user_role:type_name -> common.UserRole
-	0,  // 5: order_service_v1.OrderStatusUpdateResponse.status:type_name -> order_service_v1.Status
-	0,  // 6: order_service_v1.UpdateOrderStatusRequest.status:type_name -> order_service_v1.Status
-	0,  // 7: order_service_v1.UpdateOrderStatusResponse.status:type_name -> order_service_v1.Status
-	8,  // [8:8] is the sub-list for method output_type
-	8,  // [8:8] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	0,   // 0: order_service_v1.GetOrderStatusResponse.status:type_name -> order_service_v1.Status
+	1,   // 1: order_service_v1.GetOrderStatusResponse.status_reason:type_name -> order_service_v1.StatusReason
+	74,  // 2: order_service_v1.GetOrderStatusResponse.created_at:type_name -> google.protobuf.Timestamp
+	74,  // 3: order_service_v1.GetOrderStatusResponse.updated_at:type_name -> google.protobuf.Timestamp
+	74,  // 4: order_service_v1.GetOrderStatusResponse.terminal_at:type_name -> google.protobuf.Timestamp
+	75,  // 5: order_service_v1.CreateOrderRequest.user_role:type_name -> common.UserRole
+	4,   // 6: order_service_v1.CreateOrderRequest.order_type:type_name -> order_service_v1.OrderType
+	3,   // 7: order_service_v1.CreateOrderRequest.time_in_force:type_name -> order_service_v1.TimeInForce
+	74,  // 8: order_service_v1.CreateOrderRequest.expire_at:type_name -> google.protobuf.Timestamp
+	2,   // 9: order_service_v1.CreateOrderRequest.side:type_name -> order_service_v1.Side
+	0,   // 10: order_service_v1.CreateOrderResponse.status:type_name -> order_service_v1.Status
+	1,   // 11: order_service_v1.CreateOrderResponse.status_reason:type_name -> order_service_v1.StatusReason
+	74,  // 12: order_service_v1.CreateOrderResponse.updated_at:type_name -> google.protobuf.Timestamp
+	74,  // 13: order_service_v1.CreateOrderResponse.created_at:type_name -> google.protobuf.Timestamp
+	74,  // 14: order_service_v1.CreateOrderResponse.terminal_at:type_name -> google.protobuf.Timestamp
+	4,   // 15: order_service_v1.Order.order_type:type_name -> order_service_v1.OrderType
+	0,   // 16: order_service_v1.Order.status:type_name -> order_service_v1.Status
+	3,   // 17: order_service_v1.Order.time_in_force:type_name -> order_service_v1.TimeInForce
+	74,  // 18: order_service_v1.Order.expire_at:type_name -> google.protobuf.Timestamp
+	2,   // 19: order_service_v1.Order.side:type_name -> order_service_v1.Side
+	74,  // 20: order_service_v1.Order.triggered_at:type_name -> google.protobuf.Timestamp
+	12,  // 21: order_service_v1.Order.history:type_name -> order_service_v1.OrderEvent
+	74,  // 22: order_service_v1.Order.created_at:type_name -> google.protobuf.Timestamp
+	74,  // 23: order_service_v1.Order.updated_at:type_name -> google.protobuf.Timestamp
+	74,  // 24: order_service_v1.Order.terminal_at:type_name -> google.protobuf.Timestamp
+	1,   // 25: order_service_v1.Order.status_reason:type_name -> order_service_v1.StatusReason
+	74,  // 26: order_service_v1.OrderEvent.at:type_name -> google.protobuf.Timestamp
+	11,  // 27: order_service_v1.GetOrderResponse.order:type_name -> order_service_v1.Order
+	0,   // 28: order_service_v1.CancelOrderResponse.status:type_name -> order_service_v1.Status
+	1,   // 29: order_service_v1.CancelOrderResponse.status_reason:type_name -> order_service_v1.StatusReason
+	74,  // 30: order_service_v1.CancelOrderResponse.updated_at:type_name -> google.protobuf.Timestamp
+	74,  // 31: order_service_v1.CancelOrderResponse.created_at:type_name -> google.protobuf.Timestamp
+	74,  // 32: order_service_v1.CancelOrderResponse.terminal_at:type_name -> google.protobuf.Timestamp
+	75,  // 33: order_service_v1.StreamOrderUpdatesRequest.user_role:type_name -> common.UserRole
+	0,   // 34: order_service_v1.OrderStatusUpdateResponse.status:type_name -> order_service_v1.Status
+	11,  // 35: order_service_v1.OrderStatusUpdateResponse.order:type_name -> order_service_v1.Order
+	74,  // 36: order_service_v1.CancelAllAfterResponse.cancel_at:type_name -> google.protobuf.Timestamp
+	0,   // 37: order_service_v1.UpdateOrderStatusRequest.status:type_name -> order_service_v1.Status
+	0,   // 38: order_service_v1.UpdateOrderStatusResponse.status:type_name -> order_service_v1.Status
+	1,   // 39: order_service_v1.UpdateOrderStatusResponse.status_reason:type_name -> order_service_v1.StatusReason
+	74,  // 40: order_service_v1.UpdateOrderStatusResponse.updated_at:type_name -> google.protobuf.Timestamp
+	74,  // 41: order_service_v1.UpdateOrderStatusResponse.created_at:type_name -> google.protobuf.Timestamp
+	74,  // 42: order_service_v1.UpdateOrderStatusResponse.terminal_at:type_name -> google.protobuf.Timestamp
+	75,  // 43: order_service_v1.RefreshMarketCatalogRequest.user_roles:type_name -> common.UserRole
+	75,  // 44: order_service_v1.RefreshMarketCatalogRequest.user_role:type_name -> common.UserRole
+	75,  // 45: order_service_v1.MarketCatalogState.user_role:type_name -> common.UserRole
+	74,  // 46: order_service_v1.MarketCatalogState.synced_at:type_name -> google.protobuf.Timestamp
+	26,  // 47: order_service_v1.RefreshMarketCatalogResponse.catalogs:type_name -> order_service_v1.MarketCatalogState
+	75,  // 48: order_service_v1.CreateOrdersRequest.user_role:type_name -> common.UserRole
+	9,   // 49: order_service_v1.CreateOrdersRequest.orders:type_name -> order_service_v1.CreateOrderRequest
+	5,   // 50: order_service_v1.CreateOrdersRequest.mode:type_name -> order_service_v1.BatchMode
+	0,   // 51: order_service_v1.CreateOrderResult.status:type_name -> order_service_v1.Status
+	28,  // 52: order_service_v1.CreateOrderResult.error:type_name -> order_service_v1.ItemError
+	1,   // 53: order_service_v1.CreateOrderResult.status_reason:type_name -> order_service_v1.StatusReason
+	74,  // 54: order_service_v1.CreateOrderResult.updated_at:type_name -> google.protobuf.Timestamp
+	74,  // 55: order_service_v1.CreateOrderResult.created_at:type_name -> google.protobuf.Timestamp
+	74,  // 56: order_service_v1.CreateOrderResult.terminal_at:type_name -> google.protobuf.Timestamp
+	30,  // 57: order_service_v1.CreateOrdersResponse.results:type_name -> order_service_v1.CreateOrderResult
+	15,  // 58: order_service_v1.CancelOrdersRequest.orders:type_name -> order_service_v1.CancelOrderRequest
+	5,   // 59: order_service_v1.CancelOrdersRequest.mode:type_name -> order_service_v1.BatchMode
+	0,   // 60: order_service_v1.CancelOrderResult.status:type_name -> order_service_v1.Status
+	28,  // 61: order_service_v1.CancelOrderResult.error:type_name -> order_service_v1.ItemError
+	1,   // 62: order_service_v1.CancelOrderResult.status_reason:type_name -> order_service_v1.StatusReason
+	74,  // 63: order_service_v1.CancelOrderResult.updated_at:type_name -> google.protobuf.Timestamp
+	74,  // 64: order_service_v1.CancelOrderResult.created_at:type_name -> google.protobuf.Timestamp
+	74,  // 65: order_service_v1.CancelOrderResult.terminal_at:type_name -> google.protobuf.Timestamp
+	33,  // 66: order_service_v1.CancelOrdersResponse.results:type_name -> order_service_v1.CancelOrderResult
+	75,  // 67: order_service_v1.SetReferencePriceRequest.user_role:type_name -> common.UserRole
+	37,  // 68: order_service_v1.GetOrderBookResponse.bids:type_name -> order_service_v1.PriceLevel
+	37,  // 69: order_service_v1.GetOrderBookResponse.asks:type_name -> order_service_v1.PriceLevel
+	2,   // 70: order_service_v1.LevelUpdate.side:type_name -> order_service_v1.Side
+	37,  // 71: order_service_v1.LevelUpdate.level:type_name -> order_service_v1.PriceLevel
+	37,  // 72: order_service_v1.OrderBookUpdate.bids:type_name -> order_service_v1.PriceLevel
+	37,  // 73: order_service_v1.OrderBookUpdate.asks:type_name -> order_service_v1.PriceLevel
+	41,  // 74: order_service_v1.OrderBookUpdate.updates:type_name -> order_service_v1.LevelUpdate
+	74,  // 75: order_service_v1.Trade.executed_at:type_name -> google.protobuf.Timestamp
+	2,   // 76: order_service_v1.Trade.taker_side:type_name -> order_service_v1.Side
+	43,  // 77: order_service_v1.ListTradesResponse.trades:type_name -> order_service_v1.Trade
+	75,  // 78: order_service_v1.CreditAccountRequest.user_role:type_name -> common.UserRole
+	46,  // 79: order_service_v1.CreditAccountResponse.balance:type_name -> order_service_v1.Balance
+	75,  // 80: order_service_v1.DebitAccountRequest.user_role:type_name -> common.UserRole
+	46,  // 81: order_service_v1.DebitAccountResponse.balance:type_name -> order_service_v1.Balance
+	46,  // 82: order_service_v1.GetBalancesResponse.balances:type_name -> order_service_v1.Balance
+	74,  // 83: order_service_v1.Position.updated_at:type_name -> google.protobuf.Timestamp
+	53,  // 84: order_service_v1.GetPositionsResponse.positions:type_name -> order_service_v1.Position
+	53,  // 85: order_service_v1.PositionUpdate.position:type_name -> order_service_v1.Position
+	6,   // 86: order_service_v1.MarketState.mode:type_name -> order_service_v1.MarketMode
+	74,  // 87: order_service_v1.MarketState.updated_at:type_name -> google.protobuf.Timestamp
+	75,  // 88: order_service_v1.HaltMarketRequest.user_role:type_name -> common.UserRole
+	58,  // 89: order_service_v1.HaltMarketResponse.state:type_name -> order_service_v1.MarketState
+	75,  // 90: order_service_v1.ResumeMarketRequest.user_role:type_name -> common.UserRole
+	58,  // 91: order_service_v1.ResumeMarketResponse.state:type_name -> order_service_v1.MarketState
+	75,  // 92: order_service_v1.SetMarketCancelOnlyRequest.user_role:type_name -> common.UserRole
+	58,  // 93: order_service_v1.SetMarketCancelOnlyResponse.state:type_name -> order_service_v1.MarketState
+	75,  // 94: order_service_v1.MassCancelRequest.user_role:type_name -> common.UserRole
+	2,   // 95: order_service_v1.MassCancelRequest.side:type_name -> order_service_v1.Side
+	0,   // 96: order_service_v1.MassCancelRequest.status:type_name -> order_service_v1.Status
+	75,  // 97: order_service_v1.AmendOrderRequest.user_role:type_name -> common.UserRole
+	11,  // 98: order_service_v1.AmendOrderResponse.order:type_name -> order_service_v1.Order
+	2,   // 99: order_service_v1.ListOrdersRequest.side:type_name -> order_service_v1.Side
+	0,   // 100: order_service_v1.ListOrdersRequest.status:type_name -> order_service_v1.Status
+	11,  // 101: order_service_v1.ListOrdersResponse.orders:type_name -> order_service_v1.Order
+	9,   // 102: order_service_v1.ValidateOrderRequest.order:type_name -> order_service_v1.CreateOrderRequest
+	28,  // 103: order_service_v1.ValidateOrderResponse.violations:type_name -> order_service_v1.ItemError
+	104, // [104:104] is the sub-list for method output_type
+	104, // [104:104] is the sub-list for method input_type
+	104, // [104:104] is the sub-list for extension type_name
+	104, // [104:104] is the sub-list for extension extendee
+	0,   // [0:104] is the sub-list for field type_name
 }
 
 func init() { file_order_service_v1_order_service_messages_proto_init() }
@@ -620,13 +5548,16 @@ func file_order_service_v1_order_service_messages_proto_init() {
 	if File_order_service_v1_order_service_messages_proto != nil {
 		return
 	}
+	file_order_service_v1_order_service_messages_proto_msgTypes[59].OneofWrappers = []any{}
+	file_order_service_v1_order_service_messages_proto_msgTypes[61].OneofWrappers = []any{}
+	file_order_service_v1_order_service_messages_proto_msgTypes[63].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_order_service_v1_order_service_messages_proto_rawDesc), len(file_order_service_v1_order_service_messages_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   8,
+			NumEnums:      7,
+			NumMessages:   67,
 			NumExtensions: 0,
 			NumServices:   0,
 		},